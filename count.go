@@ -0,0 +1,117 @@
+package gormextraclauseplugin
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+)
+
+// countWrappedClauses are clause names that make COUNT of the first SELECT wrong,
+// so the whole query is counted as derived table instead
+var countWrappedClauses = []string{"WITH", "UNION", "INTERSECT", "EXCEPT"}
+
+// countLeadingClauses are clause names written before the count instead of the inner query
+var countLeadingClauses = []string{"COMMENT", "WITH"}
+
+// countTrailingClauses are clause names written after the counted derived table instead of the inner query
+var countTrailingClauses = []string{sqlCommenterClause}
+
+// countAlias is the alias of the derived table counted by wrapCount
+const countAlias = "t"
+
+// wrapCount builds Count of statements that have set operation or WITH clause as
+// WITH ... SELECT count(*) FROM (SELECT ... UNION SELECT ...) AS t.
+// Statements that have GROUP BY clause are left to gorm, which counts the returned rows.
+func wrapCount(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.SQL.Len() > 0 || !isCount(stmt) || !hasAnyClause(stmt, countWrappedClauses) {
+		return
+	}
+	if _, ok := stmt.Clauses["GROUP BY"]; ok {
+		return
+	}
+
+	countClause := stmt.Clauses["SELECT"]
+	outerClauses := map[string]clause.Clause{}
+	for _, name := range append(countLeadingClauses, countTrailingClauses...) {
+		if c, ok := stmt.Clauses[name]; ok {
+			outerClauses[name] = c
+			delete(stmt.Clauses, name)
+		}
+	}
+	delete(stmt.Clauses, "SELECT")
+	defer func() {
+		stmt.Clauses["SELECT"] = countClause
+		for name, c := range outerClauses {
+			stmt.Clauses[name] = c
+		}
+	}()
+
+	// vars are bound in the written order, so the outer query is written before the inner query is built
+	for _, name := range countLeadingClauses {
+		if c, ok := outerClauses[name]; ok {
+			buildClause(db, name, c)
+			stmt.WriteByte(' ')
+		}
+	}
+	stmt.WriteString("SELECT ")
+	if countClause.AfterNameExpression != nil {
+		countClause.AfterNameExpression.Build(stmt)
+		stmt.WriteByte(' ')
+	}
+	countClause.Expression.Build(stmt)
+	stmt.WriteString(" FROM (")
+	outer := stmt.SQL.String()
+	stmt.SQL.Reset()
+
+	callbacks.BuildQuerySQL(db)
+	inner := stmt.SQL.String()
+
+	stmt.SQL.Reset()
+	stmt.WriteString(outer)
+	stmt.WriteString(inner)
+	stmt.WriteString(") ")
+	if db.Dialector.Name() != "oracle" {
+		stmt.WriteString("AS ")
+	}
+	stmt.WriteQuoted(countAlias)
+	for _, name := range countTrailingClauses {
+		if c, ok := outerClauses[name]; ok {
+			stmt.WriteByte(' ')
+			buildClause(db, name, c)
+		}
+	}
+}
+
+// buildClause builds the clause with the clause builder of the dialect if exists, as gorm.Statement.Build does
+func buildClause(db *gorm.DB, name string, c clause.Clause) {
+	if builder, ok := db.ClauseBuilders[name]; ok {
+		builder(c, db.Statement)
+	} else {
+		c.Build(db.Statement)
+	}
+}
+
+// isCount reports whether the statement is built by Count, or selects only count into int64
+func isCount(stmt *gorm.Statement) bool {
+	if _, ok := stmt.Dest.(*int64); !ok {
+		return false
+	}
+	c, ok := stmt.Clauses["SELECT"]
+	if !ok {
+		return false
+	}
+	expr, ok := c.Expression.(clause.Expr)
+	return ok && strings.HasPrefix(strings.ToLower(strings.TrimSpace(expr.SQL)), "count(")
+}
+
+func hasAnyClause(stmt *gorm.Statement, names []string) bool {
+	for _, name := range names {
+		if _, ok := stmt.Clauses[name]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package gormextraclauseplugin
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/WinterYukky/gorm-extra-clause-plugin/exclause"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestWrapCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation func(db *gorm.DB, count *int64) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "When has UNION, then should count whole query",
+			operation: func(db *gorm.DB, count *int64) *gorm.DB {
+				return db.Table("general_users").Clauses(exclause.NewUnion(db.Table("admin_users"))).Count(count)
+			},
+			want:     "SELECT count(*) FROM (SELECT * FROM `general_users` UNION SELECT * FROM `admin_users`) AS `t`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When has INTERSECT and ORDER BY, then should count whole query without ORDER BY",
+			operation: func(db *gorm.DB, count *int64) *gorm.DB {
+				return db.Table("general_users").Where("`age` > ?", 20).Clauses(exclause.NewIntersect(db.Table("admin_users").Where("`name` = ?", "WinterYukky"))).Order("`id`").Count(count)
+			},
+			want:     "SELECT count(*) FROM (SELECT * FROM `general_users` WHERE `age` > ? INTERSECT SELECT * FROM `admin_users` WHERE `name` = ?) AS `t`",
+			wantArgs: []driver.Value{20, "WinterYukky"},
+		},
+		{
+			name: "When has EXCEPT and LIMIT, then should count the page",
+			operation: func(db *gorm.DB, count *int64) *gorm.DB {
+				return db.Table("general_users").Clauses(exclause.NewExcept(db.Table("admin_users"))).Limit(10).Offset(20).Count(count)
+			},
+			want:     "SELECT count(*) FROM (SELECT * FROM `general_users` EXCEPT SELECT * FROM `admin_users` LIMIT ? OFFSET ?) AS `t`",
+			wantArgs: []driver.Value{10, 20},
+		},
+		{
+			name: "When has WITH, then should write WITH before count",
+			operation: func(db *gorm.DB, count *int64) *gorm.DB {
+				return db.Clauses(exclause.NewWith("cte", db.Table("users").Where("`age` > ?", 20))).Table("cte").Where("`name` = ?", "WinterYukky").Count(count)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` WHERE `age` > ?) SELECT count(*) FROM (SELECT * FROM `cte` WHERE `name` = ?) AS `t`",
+			wantArgs: []driver.Value{20, "WinterYukky"},
+		},
+		{
+			name: "When has WITH and UNION, then should write WITH before count",
+			operation: func(db *gorm.DB, count *int64) *gorm.DB {
+				return db.Clauses(exclause.NewWith("cte", db.Table("users").Where("`age` > ?", 20))).
+					Table("cte").Clauses(exclause.NewUnion(db.Table("admin_users").Where("`name` = ?", "WinterYukky"))).Count(count)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` WHERE `age` > ?) SELECT count(*) FROM (SELECT * FROM `cte` UNION SELECT * FROM `admin_users` WHERE `name` = ?) AS `t`",
+			wantArgs: []driver.Value{20, "WinterYukky"},
+		},
+		{
+			name: "When counts distinct column, then should count the column of whole query",
+			operation: func(db *gorm.DB, count *int64) *gorm.DB {
+				return db.Table("general_users").Distinct("name").Clauses(exclause.NewUnion(db.Table("admin_users").Select("name"))).Count(count)
+			},
+			want:     "SELECT COUNT(DISTINCT(`name`)) FROM (SELECT DISTINCT name FROM `general_users` UNION SELECT name FROM `admin_users`) AS `t`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When has no set operation, then should not be wrapped",
+			operation: func(db *gorm.DB, count *int64) *gorm.DB {
+				return db.Table("general_users").Where("`age` > ?", 20).Count(count)
+			},
+			want:     "SELECT count(*) FROM `general_users` WHERE `age` > ?",
+			wantArgs: []driver.Value{20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db, _ := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}))
+			db.Use(New())
+			mock.ExpectQuery("^" + regexp.QuoteMeta(tt.want) + "$").WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+			var count int64
+			db = tt.operation(db, &count)
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if count != 3 {
+				t.Errorf("count is %v, want %v", count, 3)
+			}
+		})
+	}
+}
+
+func TestWrapCount_Pagination(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}))
+	db.Use(New())
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM (SELECT * FROM `general_users` UNION ALL SELECT * FROM `admin_users`) AS `t`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(25))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `general_users` UNION ALL SELECT * FROM `admin_users` ORDER BY `id` LIMIT ? OFFSET ?")).
+		WithArgs(10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21).AddRow(22).AddRow(23).AddRow(24).AddRow(25))
+
+	query := db.Table("general_users").Clauses(exclause.NewUnion("ALL ?", db.Table("admin_users"))).Order("`id`")
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		t.Fatal(err)
+	}
+	var users []map[string]interface{}
+	if err := query.Limit(10).Offset(20).Find(&users).Error; err != nil {
+		t.Fatal(err)
+	}
+	if total != 25 {
+		t.Errorf("total is %v, want %v", total, 25)
+	}
+	if len(users) != 5 {
+		t.Errorf("page size is %v, want %v", len(users), 5)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
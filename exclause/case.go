@@ -0,0 +1,100 @@
+package exclause
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CaseExpr is CASE expression.
+// It is searched CASE when Operand is nil, and the conditions are clause.Expression such as clause.Eq.
+// Otherwise it is simple CASE, and the conditions are compared with Operand.
+// Values are bind variables, clause.Column is quoted and *gorm.DB is subquery.
+//
+//	// examples
+//	// SELECT `id`,CASE WHEN `age` < 20 THEN 'minor' WHEN `age` < 65 THEN 'adult' ELSE 'senior' END AS `label` FROM `users`
+//	label := exclause.Case().
+//		When(clause.Lt{Column: "age", Value: 20}, "minor").
+//		When(clause.Lt{Column: "age", Value: 65}, "adult").
+//		Else("senior")
+//	db.Table("users").Select("`id`,? AS `label`", label).Scan(&users)
+//
+//	// SELECT * FROM `tasks` ORDER BY CASE `priority` WHEN 'high' THEN 1 WHEN 'low' THEN 3 ELSE 2 END
+//	db.Table("tasks").Order(clause.OrderBy{Expression: exclause.Case(clause.Column{Name: "priority"}).When("high", 1).When("low", 3).Else(2)}).Scan(&tasks)
+//
+//	// UPDATE `users` SET `rank`=CASE WHEN `score` >= 90 THEN 'A' ELSE 'B' END WHERE `active` = true
+//	db.Table("users").Where("`active` = ?", true).Updates(map[string]interface{}{"rank": exclause.Case().When(clause.Gte{Column: "score", Value: 90}, "A").Else("B")})
+type CaseExpr struct {
+	Operand interface{}
+	Whens   []CaseWhen
+	// ElseValue is the result when no condition matches, nil means NULL
+	ElseValue interface{}
+}
+
+// CaseWhen is WHEN ... THEN ... of CASE expression
+type CaseWhen struct {
+	Condition interface{}
+	Result    interface{}
+}
+
+// Case starts CASE expression, searched CASE without operand and simple CASE with operand
+func Case(operand ...interface{}) CaseExpr {
+	if len(operand) > 0 {
+		return CaseExpr{Operand: operand[0]}
+	}
+	return CaseExpr{}
+}
+
+// When adds WHEN condition THEN result
+func (c CaseExpr) When(condition interface{}, result interface{}) CaseExpr {
+	whens := make([]CaseWhen, len(c.Whens), len(c.Whens)+1)
+	copy(whens, c.Whens)
+	c.Whens = append(whens, CaseWhen{Condition: condition, Result: result})
+	return c
+}
+
+// Else sets ELSE result
+func (c CaseExpr) Else(result interface{}) CaseExpr {
+	c.ElseValue = result
+	return c
+}
+
+// Build build CASE expression.
+// CASE needs at least one WHEN, so the ELSE result, or NULL without it, is written alone when no condition is given.
+func (c CaseExpr) Build(builder clause.Builder) {
+	if len(c.Whens) == 0 {
+		if c.ElseValue == nil {
+			builder.WriteString("NULL")
+			return
+		}
+		writeCaseValue(builder, c.ElseValue)
+		return
+	}
+	builder.WriteString("CASE ")
+	if c.Operand != nil {
+		writeCaseValue(builder, c.Operand)
+		builder.WriteByte(' ')
+	}
+	for _, when := range c.Whens {
+		builder.WriteString("WHEN ")
+		writeCaseValue(builder, when.Condition)
+		builder.WriteString(" THEN ")
+		writeCaseValue(builder, when.Result)
+		builder.WriteByte(' ')
+	}
+	if c.ElseValue != nil {
+		builder.WriteString("ELSE ")
+		writeCaseValue(builder, c.ElseValue)
+		builder.WriteByte(' ')
+	}
+	builder.WriteString("END")
+}
+
+func writeCaseValue(builder clause.Builder, value interface{}) {
+	if db, ok := value.(*gorm.DB); ok {
+		builder.WriteByte('(')
+		builder.AddVar(builder, db)
+		builder.WriteByte(')')
+		return
+	}
+	builder.AddVar(builder, value)
+}
@@ -0,0 +1,140 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestCase(t *testing.T) {
+	tests := []struct {
+		name      string
+		exec      bool
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "When searched case is selected, then should bind values",
+			operation: func(db *gorm.DB) *gorm.DB {
+				label := Case().
+					When(clause.Lt{Column: "age", Value: 20}, "minor").
+					When(clause.Lt{Column: "age", Value: 65}, "adult").
+					Else("senior")
+				return db.Table("users").Select("`id`,? AS `label`", label).Scan(nil)
+			},
+			want:     "SELECT `id`,CASE WHEN `age` < ? THEN ? WHEN `age` < ? THEN ? ELSE ? END AS `label` FROM `users`",
+			wantArgs: []driver.Value{20, "minor", 65, "adult", "senior"},
+		},
+		{
+			name: "When simple case is ordered, then should be compared with operand",
+			operation: func(db *gorm.DB) *gorm.DB {
+				priority := Case(clause.Column{Name: "priority"}).When("high", 1).When("low", 3).Else(2)
+				return db.Table("tasks").Order(clause.OrderBy{Expression: priority}).Scan(nil)
+			},
+			want:     "SELECT * FROM `tasks` ORDER BY CASE `priority` WHEN ? THEN ? WHEN ? THEN ? ELSE ? END",
+			wantArgs: []driver.Value{"high", 1, "low", 3, 2},
+		},
+		{
+			name: "When else is not given, then should not be written ELSE",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Select("?", Case().When(clause.Eq{Column: "role", Value: "admin"}, clause.Column{Name: "name"})).Scan(nil)
+			},
+			want:     "SELECT CASE WHEN `role` = ? THEN `name` END FROM `users`",
+			wantArgs: []driver.Value{"admin"},
+		},
+		{
+			name: "When when is not given, then should be written the else result alone",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Select("`id`,? AS `label`", Case().Else("none")).Scan(nil)
+			},
+			want:     "SELECT `id`,? AS `label` FROM `users`",
+			wantArgs: []driver.Value{"none"},
+		},
+		{
+			name: "When neither when nor else is given, then should be written NULL",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Select("`id`,? AS `label`", Case(clause.Column{Name: "role"})).Scan(nil)
+			},
+			want:     "SELECT `id`,NULL AS `label` FROM `users`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When result is subquery or nested case, then should be written in the case",
+			operation: func(db *gorm.DB) *gorm.DB {
+				nested := Case().When(clause.Eq{Column: "vip", Value: true}, "vip").Else("member")
+				return db.Table("users").Select("?", Case().
+					When(clause.Eq{Column: "role", Value: "admin"}, db.Table("admins").Select("`title`").Where("`admins`.`user_id` = `users`.`id`")).
+					Else(nested),
+				).Scan(nil)
+			},
+			want:     "SELECT CASE WHEN `role` = ? THEN (SELECT `title` FROM `admins` WHERE `admins`.`user_id` = `users`.`id`) ELSE CASE WHEN `vip` = ? THEN ? ELSE ? END END FROM `users`",
+			wantArgs: []driver.Value{"admin", true, "vip", "member"},
+		},
+		{
+			name: "When case is used in window function, then should be written in OVER",
+			operation: func(db *gorm.DB) *gorm.DB {
+				priority := Case(clause.Column{Name: "priority"}).When("high", 1).Else(2)
+				return db.Table("tasks").Select("`id`,ROW_NUMBER() OVER (ORDER BY ?) AS `rank`", priority).Scan(nil)
+			},
+			want:     "SELECT `id`,ROW_NUMBER() OVER (ORDER BY CASE `priority` WHEN ? THEN ? ELSE ? END) AS `rank` FROM `tasks`",
+			wantArgs: []driver.Value{"high", 1, 2},
+		},
+		{
+			name: "When case is used in CTE subquery, then should be written in the subquery",
+			operation: func(db *gorm.DB) *gorm.DB {
+				label := Case().When(clause.Gte{Column: "age", Value: 20}, "adult").Else("minor")
+				return db.Clauses(NewWith("cte", db.Table("users").Select("?", label))).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT CASE WHEN `age` >= ? THEN ? ELSE ? END FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{20, "adult", "minor"},
+		},
+		{
+			name: "When case is used in updates map, then should be set",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where("`active` = ?", true).Updates(map[string]interface{}{
+					"rank": Case().When(clause.Gte{Column: "score", Value: 90}, "A").Else("B"),
+				})
+			},
+			want:     "UPDATE `users` SET `rank`=CASE WHEN `score` >= ? THEN ? ELSE ? END WHERE `active` = ?",
+			wantArgs: []driver.Value{90, "A", "B", true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, dialectMySQL)
+			if tt.exec {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestCase_When(t *testing.T) {
+	base := Case().When(clause.Eq{Column: "a", Value: 1}, "one")
+	first := base.When(clause.Eq{Column: "a", Value: 2}, "two")
+	second := base.When(clause.Eq{Column: "a", Value: 3}, "three")
+	if !reflect.DeepEqual(first.Whens[1], CaseWhen{Condition: clause.Eq{Column: "a", Value: 2}, Result: "two"}) {
+		t.Errorf("When() shares conditions with other branch: %v", first.Whens)
+	}
+	if len(base.Whens) != 1 || len(second.Whens) != 2 {
+		t.Errorf("When() modified the receiver: %v", base.Whens)
+	}
+}
@@ -0,0 +1,62 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// claimedCTE is the name of CTE that has keys of the claimed rows
+const claimedCTE = "claimed"
+
+// ClaimRows claims up to limit rows of the table and updates them in a single statement, for job queues.
+// Rows locked by other workers are skipped, so that concurrent workers never claim the same row.
+// Candidate rows are selected by the conditions and order of db, and identified by the primary key of the model or `id`.
+//
+//	// examples
+//	// PostgreSQL, MySQL:
+//	// WITH `claimed` AS (SELECT `id` FROM `jobs` WHERE `status` = 'pending' ORDER BY `id` LIMIT 10 FOR UPDATE SKIP LOCKED)
+//	// UPDATE `jobs` SET `status`='running' WHERE `id` IN (SELECT `id` FROM `claimed`)
+//	//
+//	// SQL Server:
+//	// WITH `claimed` AS (SELECT `id` FROM `jobs` WITH (UPDLOCK, READPAST, ROWLOCK) WHERE `status` = 'pending' ORDER BY `id` OFFSET 0 ROWS FETCH FIRST 10 ROWS ONLY)
+//	// UPDATE `jobs` SET `status`='running' WHERE `id` IN (SELECT `id` FROM `claimed`)
+//	exclause.ClaimRows(db.Where("`status` = ?", "pending").Order("`id`"), "jobs", 10, map[string]interface{}{"status": "running"})
+//
+// SQLite serializes writers, so the rows are claimed without locking.
+// Oracle can't use WITH clause in UPDATE statement, so ErrUnsupportedDialect is returned.
+func ClaimRows(db *gorm.DB, table string, limit int, updates interface{}) *gorm.DB {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	dialect := statementDialect(db.Statement)
+	if dialect == dialectOracle {
+		tx.AddError(fmt.Errorf("%w: ClaimRows on %s", ErrUnsupportedDialect, dialect))
+		return tx
+	}
+
+	key := "id"
+	if s := statementSchema(db.Statement); s != nil && s.PrioritizedPrimaryField != nil {
+		key = s.PrioritizedPrimaryField.DBName
+	}
+	// the candidates are chained on a copy of db, so that the caller can reuse db after claiming
+	candidates := db.Session(&gorm.Session{}).Select("?", clause.Column{Name: key})
+	switch dialect {
+	case dialectSQLServer:
+		candidates = candidates.Table("? WITH (UPDLOCK, READPAST, ROWLOCK)", clause.Table{Name: table})
+		// OFFSET ... FETCH needs ORDER BY
+		if _, ok := candidates.Statement.Clauses["ORDER BY"]; !ok {
+			candidates = candidates.Order("(SELECT NULL)")
+		}
+		candidates = candidates.Clauses(NewFetch(limit))
+	case dialectSQLite:
+		candidates = candidates.Table(table).Limit(limit)
+	default:
+		candidates = candidates.Table(table).Limit(limit).
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked})
+	}
+
+	return tx.Clauses(NewWith(claimedCTE, candidates)).
+		Table(table).
+		Where("? IN (SELECT ? FROM ?)", clause.Column{Name: key}, clause.Column{Name: key}, clause.Table{Name: claimedCTE}).
+		Updates(updates)
+}
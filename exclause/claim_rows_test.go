@@ -0,0 +1,132 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+type claimJob struct {
+	JobID  uint `gorm:"primaryKey"`
+	Status string
+}
+
+func TestClaimRows(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When dialect is postgres, then should lock rows with SKIP LOCKED in CTE",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return ClaimRows(db.Where("`status` = ?", "pending").Order("`id`"), "jobs", 10, map[string]interface{}{"status": "running"})
+			},
+			want:     "WITH `claimed` AS (SELECT `id` FROM `jobs` WHERE `status` = ? ORDER BY `id` LIMIT ? FOR UPDATE SKIP LOCKED) UPDATE `jobs` SET `status`=? WHERE `id` IN (SELECT `id` FROM `claimed`)",
+			wantArgs: []driver.Value{"pending", 10, "running"},
+		},
+		{
+			name:    "When dialect is mysql, then should lock rows with SKIP LOCKED in CTE",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return ClaimRows(db.Where("`status` = ?", "pending"), "jobs", 5, map[string]interface{}{"status": "running"})
+			},
+			want:     "WITH `claimed` AS (SELECT `id` FROM `jobs` WHERE `status` = ? LIMIT ? FOR UPDATE SKIP LOCKED) UPDATE `jobs` SET `status`=? WHERE `id` IN (SELECT `id` FROM `claimed`)",
+			wantArgs: []driver.Value{"pending", 5, "running"},
+		},
+		{
+			name:    "When model is given, then should identify rows by its primary key",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return ClaimRows(db.Model(&claimJob{}).Where("`status` = ?", "pending"), "jobs", 10, map[string]interface{}{"status": "running"})
+			},
+			want:     "WITH `claimed` AS (SELECT `job_id` FROM `jobs` WHERE `status` = ? LIMIT ? FOR UPDATE SKIP LOCKED) UPDATE `jobs` SET `status`=? WHERE `job_id` IN (SELECT `job_id` FROM `claimed`)",
+			wantArgs: []driver.Value{"pending", 10, "running"},
+		},
+		{
+			name:    "When dialect is sqlserver, then should lock rows with table hints",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return ClaimRows(db.Where("`status` = ?", "pending").Order("`id`"), "jobs", 10, map[string]interface{}{"status": "running"})
+			},
+			want:     "WITH `claimed` AS (SELECT `id` FROM `jobs` WITH (UPDLOCK, READPAST, ROWLOCK) WHERE `status` = ? ORDER BY `id` OFFSET ? ROWS FETCH FIRST ? ROWS ONLY) UPDATE `jobs` SET `status`=? WHERE `id` IN (SELECT `id` FROM `claimed`)",
+			wantArgs: []driver.Value{"pending", 0, 10, "running"},
+		},
+		{
+			name:    "When dialect is sqlserver and order is not given, then should be ordered by nothing",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return ClaimRows(db, "jobs", 10, map[string]interface{}{"status": "running"})
+			},
+			want:     "WITH `claimed` AS (SELECT `id` FROM `jobs` WITH (UPDLOCK, READPAST, ROWLOCK) ORDER BY (SELECT NULL) OFFSET ? ROWS FETCH FIRST ? ROWS ONLY) UPDATE `jobs` SET `status`=? WHERE `id` IN (SELECT `id` FROM `claimed`)",
+			wantArgs: []driver.Value{0, 10, "running"},
+		},
+		{
+			name:    "When dialect is sqlite, then should claim rows without locking",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return ClaimRows(db.Where("`status` = ?", "pending"), "jobs", 10, map[string]interface{}{"status": "running"})
+			},
+			want:     "WITH `claimed` AS (SELECT `id` FROM `jobs` WHERE `status` = ? LIMIT ?) UPDATE `jobs` SET `status`=? WHERE `id` IN (SELECT `id` FROM `claimed`)",
+			wantArgs: []driver.Value{"pending", 10, "running"},
+		},
+		{
+			name:    "When dialect is oracle, then should be error",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return ClaimRows(db, "jobs", 10, map[string]interface{}{"status": "running"})
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestClaimRows_Reuse(t *testing.T) {
+	db, mock := openDialectDB(t, dialectPostgres)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("WITH `claimed` AS (SELECT `id` FROM `jobs` WHERE `status` = ? ORDER BY `id` LIMIT ? FOR UPDATE SKIP LOCKED) UPDATE `jobs` SET `status`=? WHERE `id` IN (SELECT `id` FROM `claimed`)")).
+		WithArgs("pending", 10, "running").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("^" + regexp.QuoteMeta("SELECT * FROM `jobs` WHERE `status` = ? ORDER BY `id`") + "$").
+		WithArgs("pending").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	pending := db.Where("`status` = ?", "pending").Order("`id`")
+	if err := ClaimRows(pending, "jobs", 10, map[string]interface{}{"status": "running"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := pending.Table("jobs").Find(&[]map[string]interface{}{}).Error; err != nil {
+		t.Error(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
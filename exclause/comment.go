@@ -0,0 +1,71 @@
+package exclause
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// Comment is leading comment clause, that helps to trace the statement in database logs
+//
+//	// examples
+//	// /* user list */ SELECT * FROM `users`
+//	db.Clauses(exclause.NewComment("user list")).Table("users").Scan(&users)
+//
+//	// /* batch */ /* job_id=42 */ UPDATE `users` SET `active`=false
+//	db.Clauses(exclause.NewComment("batch"), exclause.NewComment("job_id=42")).Table("users").Where("1 = 1").Update("active", false)
+type Comment struct {
+	Comments []string
+}
+
+// Name comment clause name
+func (comment Comment) Name() string {
+	return "COMMENT"
+}
+
+// Build build comment clause.
+// "/*" and "*/" in the comments are escaped, so that the comment can't be nested or closed by its content.
+func (comment Comment) Build(builder clause.Builder) {
+	for index, c := range comment.Comments {
+		if index > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString("/* ")
+		builder.WriteString(escapeComment(c))
+		builder.WriteString(" */")
+	}
+}
+
+// escapeComment separates "/" and "*" next to each other, as PostgreSQL allows nested comments
+func escapeComment(comment string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(comment); i++ {
+		if i > 0 && (comment[i-1] == '/' && comment[i] == '*' || comment[i-1] == '*' && comment[i] == '/') {
+			escaped.WriteByte(' ')
+		}
+		escaped.WriteByte(comment[i])
+	}
+	return escaped.String()
+}
+
+// MergeClause merge Comment clauses
+func (comment Comment) MergeClause(mergeClause *clause.Clause) {
+	if c, ok := mergeClause.Expression.(Comment); ok {
+		comments := make([]string, len(c.Comments)+len(comment.Comments))
+		copy(comments, c.Comments)
+		copy(comments[len(c.Comments):], comment.Comments)
+		comment.Comments = comments
+	}
+
+	mergeClause.Name = ""
+	mergeClause.Expression = comment
+}
+
+// NewComment is easy to create new Comment
+//
+//	// examples
+//	// /* user list */ SELECT * FROM `users`
+//	db.Clauses(exclause.NewComment("user list")).Table("users").Scan(&users)
+func NewComment(comment string) Comment {
+	return Comment{Comments: []string{comment}}
+}
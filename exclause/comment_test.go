@@ -0,0 +1,132 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		exec      bool
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "When query has Comment, then should be written first",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("user list")).Table("users").Where("`name` = ?", "WinterYukky").Scan(nil)
+			},
+			want:     "/* user list */ SELECT * FROM `users` WHERE `name` = ?",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When query has Comment and WITH, then should be written before WITH",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("users"))).Clauses(NewComment("user list")).Table("cte").Scan(nil)
+			},
+			want:     "/* user list */ WITH `cte` AS (SELECT * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When query has multiple Comment, then should be written all comments",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("batch"), NewComment("job_id=42")).Table("users").Scan(nil)
+			},
+			want:     "/* batch */ /* job_id=42 */ SELECT * FROM `users`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When Comment contains end of comment, then should be escaped",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("evil */ DROP TABLE users; /*")).Table("users").Scan(nil)
+			},
+			want:     "/* evil * / DROP TABLE users; / * */ SELECT * FROM `users`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When Comment contains start of nested comment, then should be escaped",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("nested /*/ DROP TABLE users; --")).Table("users").Scan(nil)
+			},
+			want:     "/* nested / * / DROP TABLE users; -- */ SELECT * FROM `users`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When CTE subquery has Comment, then should be written in the subquery",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Clauses(NewComment("inner")).Table("users"))).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (/* inner */ SELECT * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When count has Comment and UNION, then should be written before count",
+			operation: func(db *gorm.DB) *gorm.DB {
+				var count int64
+				return db.Clauses(NewComment("user count")).Table("general_users").Clauses(NewUnion(db.Table("admin_users"))).Count(&count)
+			},
+			want:     "/* user count */ SELECT count(*) FROM (SELECT * FROM `general_users` UNION SELECT * FROM `admin_users`) AS `t`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When create has Comment, then should be written before INSERT",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("signup")).Table("users").Create(map[string]interface{}{"name": "WinterYukky"})
+			},
+			want:     "/* signup */ INSERT INTO `users` (`name`) VALUES (?)",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When update has Comment, then should be written before UPDATE",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("rename")).Table("users").Where("`id` = ?", 1).Update("name", "WinterYukky")
+			},
+			want:     "/* rename */ UPDATE `users` SET `name`=? WHERE `id` = ?",
+			wantArgs: []driver.Value{"WinterYukky", 1},
+		},
+		{
+			name: "When delete has Comment, then should be written before DELETE",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("cleanup")).Table("users").Where("`id` = ?", 1).Delete(nil)
+			},
+			want:     "/* cleanup */ DELETE FROM `users` WHERE `id` = ?",
+			wantArgs: []driver.Value{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, dialectMySQL)
+			if tt.exec {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewComment(t *testing.T) {
+	want := Comment{Comments: []string{"user list"}}
+	if got := NewComment("user list"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewComment() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// CTERef is a handle of CTE whose rows are T.
+// The name of CTE is written once, and columns are resolved against the schema of T.
+//
+//	// examples
+//	type Admin struct {
+//		ID   uint
+//		Name string
+//	}
+//	admins := exclause.NewCTERef[Admin]("admins", db.Table("users").Where("`role` = ?", "admin"))
+//
+//	// WITH `admins` AS (SELECT * FROM `users` WHERE `role` = 'admin') SELECT * FROM `admins` WHERE `admins`.`name` = 'WinterYukky'
+//	db.Scopes(admins.From()).Where(clause.Eq{Column: admins.Col("Name"), Value: "WinterYukky"}).Find(&[]Admin{})
+//
+//	// WITH `admins` AS (SELECT * FROM `users` WHERE `role` = 'admin') SELECT `users`.* FROM `users` JOIN `admins` ON `admins`.`id` = `users`.`id`
+//	db.Clauses(admins.With()).Table("users").Select("`users`.*").Joins("JOIN ? ON ? = `users`.`id`", admins.Table(), admins.Col("ID")).Find(&users)
+type CTERef[T any] struct {
+	CTE CTE
+}
+
+// NewCTERef creates a new CTERef.
+// The subquery can be *gorm.DB, raw SQL string or clause.Expression.
+func NewCTERef[T any](name string, subquery interface{}, args ...interface{}) CTERef[T] {
+	return CTERef[T]{CTE: NewCTE(name, subquery, args...)}
+}
+
+// With returns the WITH clause that defines the CTE
+func (ref CTERef[T]) With() With {
+	return With{CTEs: []CTE{ref.CTE}}
+}
+
+// Table returns the CTE as table
+func (ref CTERef[T]) Table() clause.Table {
+	return clause.Table{Name: ref.CTE.Name}
+}
+
+// Col returns the column of the CTE for the field name or column name of T.
+// The column is resolved with the naming strategy of the statement when it is built,
+// and the statement fails with gorm.ErrInvalidField if T has no such field.
+func (ref CTERef[T]) Col(field string) clause.Expr {
+	return clause.Expr{SQL: "?", Vars: []interface{}{cteColumn{CTE: ref.CTE.Name, Field: field, Model: new(T)}}}
+}
+
+// cteColumn is a column of CTERef which is resolved while the statement is built
+type cteColumn struct {
+	CTE   string
+	Field string
+	Model interface{}
+}
+
+// Build build the column of CTE
+func (column cteColumn) Build(builder clause.Builder) {
+	var namer schema.Namer = schema.NamingStrategy{}
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.NamingStrategy != nil {
+		namer = stmt.NamingStrategy
+	}
+	s, err := schema.Parse(column.Model, schemaCache(namer), namer)
+	if err != nil {
+		builder.AddError(fmt.Errorf("failed to parse schema of CTE %s: %w", column.CTE, err))
+		return
+	}
+	f := s.LookUpField(column.Field)
+	if f == nil || f.DBName == "" {
+		builder.AddError(fmt.Errorf("%w: CTE %s has no field %s", gorm.ErrInvalidField, column.CTE, column.Field))
+		return
+	}
+	builder.WriteQuoted(clause.Column{Table: column.CTE, Name: f.DBName})
+}
+
+// From returns the scope that defines the CTE and selects from it
+//
+//	// WITH `admins` AS (SELECT * FROM `users` WHERE `role` = 'admin') SELECT * FROM `admins`
+//	db.Scopes(admins.From()).Find(&[]Admin{})
+func (ref CTERef[T]) From() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Clauses(ref.With()).Table(ref.CTE.Name)
+	}
+}
@@ -0,0 +1,155 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	extraClausePlugin "github.com/WinterYukky/gorm-extra-clause-plugin"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type cteAdmin struct {
+	ID       uint
+	UserName string
+	Ignored  string `gorm:"-"`
+}
+
+func TestCTERef_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "When used From scope, then should select from CTE",
+			operation: func(db *gorm.DB) *gorm.DB {
+				admins := NewCTERef[cteAdmin]("admins", db.Table("users").Where("`role` = ?", "admin"))
+				return db.Scopes(admins.From()).Find(&[]cteAdmin{})
+			},
+			want:     "WITH `admins` AS (SELECT * FROM `users` WHERE `role` = ?) SELECT * FROM `admins`",
+			wantArgs: []driver.Value{"admin"},
+		},
+		{
+			name: "When used Col in condition, then should be used column of CTE",
+			operation: func(db *gorm.DB) *gorm.DB {
+				admins := NewCTERef[cteAdmin]("admins", "SELECT * FROM `users` WHERE `role` = ?", "admin")
+				return db.Scopes(admins.From()).Where(clause.Eq{Column: admins.Col("UserName"), Value: "WinterYukky"}).Find(&[]cteAdmin{})
+			},
+			want:     "WITH `admins` AS (SELECT * FROM `users` WHERE `role` = ?) SELECT * FROM `admins` WHERE `admins`.`user_name` = ?",
+			wantArgs: []driver.Value{"admin", "WinterYukky"},
+		},
+		{
+			name: "When used Table and Col in join, then should be used CTE as table",
+			operation: func(db *gorm.DB) *gorm.DB {
+				admins := NewCTERef[cteAdmin]("admins", db.Table("users").Where("`role` = ?", "admin"))
+				return db.Clauses(admins.With()).Table("users").Select("`users`.*").Joins("JOIN ? ON ? = `users`.`id`", admins.Table(), admins.Col("id")).Scan(nil)
+			},
+			want:     "WITH `admins` AS (SELECT * FROM `users` WHERE `role` = ?) SELECT `users`.* FROM `users` JOIN `admins` ON `admins`.`id` = `users`.`id`",
+			wantArgs: []driver.Value{"admin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db, _ := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}))
+			db.Use(extraClausePlugin.New())
+			mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+		})
+	}
+}
+
+func TestCTERef_Col(t *testing.T) {
+	admins := NewCTERef[cteAdmin]("admins", "SELECT * FROM `users`")
+	tests := []struct {
+		name           string
+		field          string
+		namingStrategy schema.Namer
+		want           string
+		wantErr        error
+	}{
+		{
+			name:  "When field name is given, then should be resolved to column name",
+			field: "UserName",
+			want:  "SELECT * FROM `admins` WHERE `admins`.`user_name` = ?",
+		},
+		{
+			name:  "When column name is given, then should be used as is",
+			field: "user_name",
+			want:  "SELECT * FROM `admins` WHERE `admins`.`user_name` = ?",
+		},
+		{
+			name:           "When db has naming strategy, then should be resolved with it",
+			field:          "UserName",
+			namingStrategy: schema.NamingStrategy{NoLowerCase: true},
+			want:           "SELECT * FROM `admins` WHERE `admins`.`UserName` = ?",
+		},
+		{
+			name:    "When field doesn't exist, then should be error",
+			field:   "Username",
+			wantErr: gorm.ErrInvalidField,
+		},
+		{
+			name:    "When field is ignored, then should be error",
+			field:   "Ignored",
+			wantErr: gorm.ErrInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := openDialectDB(t, dialectMySQL)
+			if tt.namingStrategy != nil {
+				db.NamingStrategy = tt.namingStrategy
+			}
+			got, _, err := ToSQL(db, func(tx *gorm.DB) *gorm.DB {
+				return tx.Table("admins").Where(clause.Eq{Column: admins.Col(tt.field), Value: "WinterYukky"}).Find(&[]cteAdmin{})
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error is %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Col() sql = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCTERef(t *testing.T) {
+	got := NewCTERef[cteAdmin]("admins", "SELECT * FROM `users` WHERE `role` = ?", "admin")
+	want := CTERef[cteAdmin]{CTE: CTE{Name: "admins", Subquery: clause.Expr{SQL: "SELECT * FROM `users` WHERE `role` = ?", Vars: []interface{}{"admin"}}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCTERef() = %v, want %v", got, want)
+	}
+	if got.Table() != (clause.Table{Name: "admins"}) {
+		t.Errorf("Table() = %v, want %v", got.Table(), clause.Table{Name: "admins"})
+	}
+	if !reflect.DeepEqual(got.With(), With{CTEs: []CTE{want.CTE}}) {
+		t.Errorf("With() = %v, want %v", got.With(), With{CTEs: []CTE{want.CTE}})
+	}
+}
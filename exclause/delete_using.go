@@ -0,0 +1,104 @@
+package exclause
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DeleteUsing is DELETE ... USING clause, or DELETE ... CROSS JOIN on MySQL and SQL Server.
+// gorm turns the delete of soft deleted model into UPDATE, so the statement needs Unscoped, or use UpdateFrom instead.
+//
+//	// examples
+//	// PostgreSQL: DELETE FROM `users` USING `profiles` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`banned` = true
+//	// MySQL:      DELETE `users` FROM `users` CROSS JOIN `profiles` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`banned` = true
+//	db.Clauses(exclause.NewDeleteUsing("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+//		Table("users").Where("`profiles`.`banned` = ?", true).Delete(nil)
+//
+//	// WITH `cte` AS (SELECT * FROM `profiles`) DELETE FROM `users` USING `cte` WHERE `users`.`id` = `cte`.`user_id`
+//	db.Clauses(exclause.NewWith("cte", db.Table("profiles"))).
+//		Clauses(exclause.NewDeleteUsing("cte", clause.Expr{SQL: "`users`.`id` = `cte`.`user_id`"})).
+//		Table("users").Delete(nil)
+type DeleteUsing struct {
+	Tables []FromTable
+}
+
+// Name delete using clause name
+func (using DeleteUsing) Name() string {
+	return "DELETE USING"
+}
+
+// Build build delete using clause
+func (using DeleteUsing) Build(builder clause.Builder) {
+	switch dialectOf(builder) {
+	case dialectMySQL, dialectSQLServer:
+		buildCrossJoinedTables(builder, using.Tables)
+	default:
+		builder.WriteString("USING ")
+		buildTableList(builder, using.Tables)
+	}
+}
+
+// MergeClause merge DeleteUsing clauses
+func (using DeleteUsing) MergeClause(mergeClause *clause.Clause) {
+	if u, ok := mergeClause.Expression.(DeleteUsing); ok {
+		tables := make([]FromTable, len(u.Tables)+len(using.Tables))
+		copy(tables, u.Tables)
+		copy(tables[len(u.Tables):], using.Tables)
+		using.Tables = tables
+	}
+
+	mergeClause.Name = ""
+	mergeClause.Expression = using
+}
+
+// ModifyStatement add DeleteUsing clause to the statement.
+// MySQL and SQL Server name the target table between DELETE and FROM when joining, so it is attached to the FROM clause there.
+// OUTPUT clause of SQL Server must precede FROM of the joined tables, so it is written after the target table instead of its own position.
+func (using DeleteUsing) ModifyStatement(stmt *gorm.Statement) {
+	addClause(stmt, using)
+
+	if dialect := statementDialect(stmt); dialect == dialectMySQL || dialect == dialectSQLServer {
+		c := stmt.Clauses["FROM"]
+		c.BeforeExpression = deleteTarget{}
+		stmt.Clauses["FROM"] = c
+		if dialect == dialectSQLServer {
+			stmt.BuildClauses = withoutClauses(deleteBuildClauses(stmt), []string{"OUTPUT"})
+		}
+	}
+	moveJoinConditions(stmt, using.Tables)
+}
+
+// deleteBuildClauses returns the clause names built by the delete statement
+func deleteBuildClauses(stmt *gorm.Statement) []string {
+	if len(stmt.BuildClauses) > 0 {
+		return stmt.BuildClauses
+	}
+	return stmt.DB.Callback().Delete().Clauses
+}
+
+// deleteTarget is the target table written between DELETE and FROM
+type deleteTarget struct{}
+
+// Build build the target table, and OUTPUT clause after it on SQL Server
+func (target deleteTarget) Build(builder clause.Builder) {
+	builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok || dialectOf(builder) != dialectSQLServer {
+		return
+	}
+	if c, ok := stmt.Clauses["OUTPUT"]; ok && c.Expression != nil {
+		builder.WriteByte(' ')
+		c.Build(builder)
+	}
+}
+
+// NewDeleteUsing is easy to create new DeleteUsing
+//
+//	// examples
+//	// DELETE FROM `users` USING `profiles` WHERE `users`.`id` = `profiles`.`user_id`
+//	db.Clauses(exclause.NewDeleteUsing("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).Table("users").Delete(nil)
+func NewDeleteUsing(table interface{}, on ...clause.Expression) DeleteUsing {
+	return DeleteUsing{
+		Tables: []FromTable{{Table: table, On: on}},
+	}
+}
@@ -0,0 +1,208 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	extraClausePlugin "github.com/WinterYukky/gorm-extra-clause-plugin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestDeleteUsing_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name:    "When dialect is postgres, then should be used USING and join condition in WHERE",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Where("`profiles`.`banned` = ?", true).Delete(nil)
+			},
+			want:     "DELETE FROM `users` USING `profiles` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`banned` = ?",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When dialect is mysql, then should be used target table and CROSS JOIN",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Where("`profiles`.`banned` = ?", true).Delete(nil)
+			},
+			want:     "DELETE `users` FROM `users` CROSS JOIN `profiles` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`banned` = ?",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When dialect is sqlserver, then should be used target table and CROSS JOIN",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Where("`profiles`.`banned` = ?", true).Delete(nil)
+			},
+			want:     "DELETE `users` FROM `users` CROSS JOIN `profiles` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`banned` = ?",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When dialect is mysql and has only join condition, then should be used join condition as WHERE",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Delete(nil)
+			},
+			want:     "DELETE `users` FROM `users` CROSS JOIN `profiles` WHERE `users`.`id` = `profiles`.`user_id`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is sqlserver and has only join condition, then should be used join condition as WHERE",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Delete(nil)
+			},
+			want:     "DELETE `users` FROM `users` CROSS JOIN `profiles` WHERE `users`.`id` = `profiles`.`user_id`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When has multiple DeleteUsing, then should be used all tables",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Clauses(NewDeleteUsing(clause.Table{Name: "teams", Alias: "t"}, clause.Expr{SQL: "`users`.`team_id` = `t`.`id`"})).
+					Table("users").Delete(nil)
+			},
+			want:     "DELETE FROM `users` USING `profiles`,`teams` `t` WHERE `users`.`id` = `profiles`.`user_id` AND `users`.`team_id` = `t`.`id`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is postgres and has WITH clause, then should be used CTE in USING",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("profiles").Where("`banned` = ?", true))).
+					Clauses(NewDeleteUsing("cte", clause.Expr{SQL: "`users`.`id` = `cte`.`user_id`"})).
+					Table("users").Delete(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `profiles` WHERE `banned` = ?) DELETE FROM `users` USING `cte` WHERE `users`.`id` = `cte`.`user_id`",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When dialect is mysql and has WITH clause, then should be used CTE in CROSS JOIN",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("profiles").Where("`banned` = ?", true))).
+					Clauses(NewDeleteUsing("cte", clause.Expr{SQL: "`users`.`id` = `cte`.`user_id`"})).
+					Table("users").Where("`cte`.`user_id` IS NOT NULL").Delete(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `profiles` WHERE `banned` = ?) DELETE `users` FROM `users` CROSS JOIN `cte` WHERE `users`.`id` = `cte`.`user_id` AND `cte`.`user_id` IS NOT NULL",
+			wantArgs: []driver.Value{true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+		})
+	}
+}
+
+type softDeleteUser struct {
+	ID        uint
+	DeletedAt gorm.DeletedAt
+}
+
+func TestDeleteUsing_SoftDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantErr   error
+	}{
+		{
+			name: "When model is soft deleted, then should be error",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`soft_delete_users`.`id` = `profiles`.`user_id`"})).
+					Where("`profiles`.`banned` = ?", true).Delete(&softDeleteUser{})
+			},
+			wantErr: extraClausePlugin.ErrUnsupportedSoftDelete,
+		},
+		{
+			name: "When statement is unscoped, then should be deleted with the joined table",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`soft_delete_users`.`id` = `profiles`.`user_id`"})).
+					Where("`profiles`.`banned` = ?", true).Unscoped().Delete(&softDeleteUser{})
+			},
+			want: "DELETE FROM `soft_delete_users` USING `profiles` WHERE `soft_delete_users`.`id` = `profiles`.`user_id` AND `profiles`.`banned` = ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, dialectPostgres)
+			if tt.wantErr == nil {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(true).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+				if !errors.Is(db.Error, gorm.ErrInvalidData) {
+					t.Errorf("error is %v, want %v", db.Error, gorm.ErrInvalidData)
+				}
+				return
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewDeleteUsing(t *testing.T) {
+	on := clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"}
+	tests := []struct {
+		name  string
+		table interface{}
+		on    []clause.Expression
+		want  DeleteUsing
+	}{
+		{
+			name:  "When has condition, then table has join condition",
+			table: "profiles",
+			on:    []clause.Expression{on},
+			want:  DeleteUsing{Tables: []FromTable{{Table: "profiles", On: []clause.Expression{on}}}},
+		},
+		{
+			name:  "When has no condition, then table has no join condition",
+			table: "profiles",
+			want:  DeleteUsing{Tables: []FromTable{{Table: "profiles"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDeleteUsing(tt.table, tt.on...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewDeleteUsing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Dialect names reported by gorm.Dialector.Name() of the official drivers
+const (
+	dialectMySQL     = "mysql"
+	dialectPostgres  = "postgres"
+	dialectSQLite    = "sqlite"
+	dialectSQLServer = "sqlserver"
+	dialectOracle    = "oracle"
+)
+
+// ErrUnsupportedDialect is added to the statement when a clause has no equivalent on the dialect
+var ErrUnsupportedDialect = fmt.Errorf("%w: not supported by the dialect", gorm.ErrNotImplemented)
+
+// dialectOf returns the dialect name of the statement that is building the clause
+func dialectOf(builder clause.Builder) string {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		return statementDialect(stmt)
+	}
+	return ""
+}
+
+// statementDialect returns the dialect name of the statement
+func statementDialect(stmt *gorm.Statement) string {
+	if stmt.DB != nil && stmt.DB.Dialector != nil {
+		return stmt.DB.Dialector.Name()
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package exclause
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	extraClausePlugin "github.com/WinterYukky/gorm-extra-clause-plugin"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// testDialector builds SQL with the MySQL dialector but reports another dialect name
+type testDialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (dialector testDialector) Name() string {
+	return dialector.name
+}
+
+// openDialectDB opens a mocked database which reports dialect as its dialect name
+func openDialectDB(t *testing.T, dialect string) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+	db, _ := gorm.Open(testDialector{
+		Dialector: mysql.New(mysql.Config{
+			Conn:                      mockDB,
+			SkipInitializeWithVersion: true,
+		}),
+		name: dialect,
+	})
+	db.Use(extraClausePlugin.New())
+	return db, mock
+}
+
+func TestDialectOf(t *testing.T) {
+	for _, dialect := range []string{dialectMySQL, dialectPostgres, dialectSQLite, dialectSQLServer, dialectOracle} {
+		t.Run(dialect, func(t *testing.T) {
+			db, _ := openDialectDB(t, dialect)
+			if got := dialectOf(db.Statement); got != dialect {
+				t.Errorf("dialectOf() = %v, want %v", got, dialect)
+			}
+		})
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"gorm.io/gorm/clause"
 )
 
-// Except is except clause
+// Except is except clause, that is rendered as MINUS on Oracle
 type Except struct {
 	Statements []clause.Expression
+	// EmulateOn are the columns compared to emulate EXCEPT with NOT EXISTS,
+	// for databases that don't support EXCEPT such as MySQL before 8.0.31.
+	// The statements must be plain queries, and duplicated rows are kept unless Distinct is used.
+	EmulateOn []string
 }
 
 // Name except clause name
@@ -17,10 +21,13 @@ func (except Except) Name() string {
 
 // Build build except clause
 func (except Except) Build(builder clause.Builder) {
+	keyword := setOperatorKeyword(builder, SetOperatorExcept)
 	for index, statement := range except.Statements {
 		if index != 0 {
-			builder.WriteString(" EXCEPT ")
+			builder.WriteByte(' ')
 		}
+		builder.WriteString(keyword)
+		builder.WriteByte(' ')
 		statement.Build(builder)
 	}
 }
@@ -34,9 +41,19 @@ func (except Except) MergeClause(mergeClause *clause.Clause) {
 		except.Statements = statements
 	}
 
+	mergeClause.Name = ""
 	mergeClause.Expression = except
 }
 
+// ModifyStatement add Except clause to the statement, or NOT EXISTS conditions when EmulateOn is set
+func (except Except) ModifyStatement(stmt *gorm.Statement) {
+	if len(except.EmulateOn) == 0 {
+		addClause(stmt, except)
+		return
+	}
+	emulateSetOperation(stmt, true, except.EmulateOn, except.Statements)
+}
+
 // NewExcept is easy to create new Except
 //
 //	// examples
@@ -48,6 +65,11 @@ func (except Except) MergeClause(mergeClause *clause.Clause) {
 //
 //	// SELECT * FROM `general_users` EXCEPT ALL SELECT * FROM `admin_users`
 //	db.Table("general_users").Clauses(exclause.NewExcept("ALL ?", db.Table("admin_users"))).Scan(&users)
+//
+//	// SELECT * FROM `general_users` WHERE NOT EXISTS (SELECT 1 FROM (SELECT * FROM `admin_users`) AS `exclause_right` WHERE `general_users`.`id` <=> `exclause_right`.`id`)
+//	except := exclause.NewExcept(db.Table("admin_users"))
+//	except.EmulateOn = []string{"id"}
+//	db.Table("general_users").Clauses(except).Scan(&users)
 func NewExcept(query interface{}, args ...interface{}) Except {
 	switch v := query.(type) {
 	case *gorm.DB:
@@ -99,6 +99,54 @@ func TestExcept_Query(t *testing.T) {
 	}
 }
 
+func TestExcept_Dialect(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name:    "When dialect is oracle, then should be used MINUS",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("general_users").
+					Clauses(NewExcept(db.Table("admin_users"))).
+					Clauses(NewExcept(db.Table("guest_users"))).Scan(nil)
+			},
+			want:     "SELECT * FROM `general_users` MINUS SELECT * FROM `admin_users` MINUS SELECT * FROM `guest_users`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When EmulateOn is set, then should be used NOT EXISTS",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("general_users").
+					Clauses(Except{
+						Statements: []clause.Expression{Subquery{DB: db.Table("admin_users").Where("`active` = ?", true)}},
+						EmulateOn:  []string{"id", "name"},
+					}).
+					Where("`age` > ?", 20).Scan(nil)
+			},
+			want:     "SELECT * FROM `general_users` WHERE NOT EXISTS (SELECT 1 FROM (SELECT * FROM `admin_users` WHERE `active` = ?) AS `exclause_right` WHERE `general_users`.`id` <=> `exclause_right`.`id` AND `general_users`.`name` <=> `exclause_right`.`name`) AND `age` > ?",
+			wantArgs: []driver.Value{true, 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+		})
+	}
+}
+
 func TestNewExcept(t *testing.T) {
 	mockDB, _, err := sqlmock.New()
 	if err != nil {
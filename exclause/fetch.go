@@ -0,0 +1,107 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrFetchWithLimit is added to the statement when Fetch clause is used with LIMIT clause, such as db.Limit
+var ErrFetchWithLimit = fmt.Errorf("%w: FETCH clause can't be used with LIMIT clause", gorm.ErrInvalidData)
+
+// Fetch is ANSI standard pagination clause, OFFSET ... ROWS FETCH FIRST ... ROWS ONLY.
+// It is supported by PostgreSQL, SQL Server and Oracle, while MySQL and SQLite have LIMIT clause only.
+// PERCENT is supported by Oracle, and WITH TIES is supported by PostgreSQL and Oracle.
+//
+//	// examples
+//	// SELECT * FROM `users` ORDER BY `age` OFFSET 20 ROWS FETCH FIRST 10 ROWS ONLY
+//	db.Table("users").Order("`age`").Clauses(exclause.Fetch{Offset: 20, Rows: 10}).Scan(&users)
+//
+//	// SELECT * FROM `users` ORDER BY `age` FETCH FIRST 3 ROWS WITH TIES
+//	db.Table("users").Order("`age`").Clauses(exclause.Fetch{Rows: 3, WithTies: true}).Scan(&users)
+//
+//	// Oracle: SELECT * FROM `users` ORDER BY `age` FETCH FIRST 10 PERCENT ROWS ONLY
+//	db.Table("users").Order("`age`").Clauses(exclause.Fetch{Rows: 10, Percent: true}).Scan(&users)
+type Fetch struct {
+	// Offset is the number of rows skipped
+	Offset int
+	// Rows is the number of fetched rows, or percentage of rows with Percent. Zero means all rows after Offset.
+	Rows     int
+	Percent  bool
+	WithTies bool
+}
+
+// Name fetch clause name
+func (fetch Fetch) Name() string {
+	return "FETCH"
+}
+
+// Build build fetch clause
+func (fetch Fetch) Build(builder clause.Builder) {
+	dialect := dialectOf(builder)
+	switch {
+	case dialect == dialectMySQL || dialect == dialectSQLite:
+		builder.AddError(fmt.Errorf("%w: FETCH on %s", ErrUnsupportedDialect, dialect))
+		return
+	case fetch.Percent && (dialect == dialectPostgres || dialect == dialectSQLServer):
+		builder.AddError(fmt.Errorf("%w: FETCH PERCENT on %s", ErrUnsupportedDialect, dialect))
+		return
+	case fetch.WithTies && dialect == dialectSQLServer:
+		builder.AddError(fmt.Errorf("%w: FETCH WITH TIES on %s", ErrUnsupportedDialect, dialect))
+		return
+	}
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		if limit, ok := stmt.Clauses["LIMIT"].Expression.(clause.Limit); ok && (limit.Limit != nil || limit.Offset > 0) {
+			builder.AddError(ErrFetchWithLimit)
+			return
+		}
+	}
+
+	// SQL Server requires OFFSET before FETCH
+	if fetch.Offset > 0 || dialect == dialectSQLServer {
+		builder.WriteString("OFFSET ")
+		builder.AddVar(builder, fetch.Offset)
+		builder.WriteString(" ROWS")
+		if fetch.Rows > 0 {
+			builder.WriteByte(' ')
+		}
+	}
+	if fetch.Rows > 0 {
+		builder.WriteString("FETCH FIRST ")
+		builder.AddVar(builder, fetch.Rows)
+		if fetch.Percent {
+			builder.WriteString(" PERCENT")
+		}
+		if fetch.WithTies {
+			builder.WriteString(" ROWS WITH TIES")
+		} else {
+			builder.WriteString(" ROWS ONLY")
+		}
+	}
+}
+
+// MergeClause merge Fetch clauses, the last one is used
+func (fetch Fetch) MergeClause(mergeClause *clause.Clause) {
+	mergeClause.Name = ""
+	mergeClause.Expression = fetch
+}
+
+// ModifyStatement add Fetch clause to the statement.
+// Zero value of Fetch has nothing to write, so it removes FETCH clause from the statement instead.
+func (fetch Fetch) ModifyStatement(stmt *gorm.Statement) {
+	if fetch.Offset == 0 && fetch.Rows == 0 {
+		delete(stmt.Clauses, fetch.Name())
+		return
+	}
+	addClause(stmt, fetch)
+}
+
+// NewFetch is easy to create new Fetch
+//
+//	// examples
+//	// SELECT * FROM `users` ORDER BY `age` FETCH FIRST 10 ROWS ONLY
+//	db.Table("users").Order("`age`").Clauses(exclause.NewFetch(10)).Scan(&users)
+func NewFetch(rows int) Fetch {
+	return Fetch{Rows: rows}
+}
@@ -0,0 +1,208 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestFetch_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When rows is given, then should fetch first rows only",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(NewFetch(10)).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY `age` FETCH FIRST ? ROWS ONLY",
+			wantArgs: []driver.Value{10},
+		},
+		{
+			name:    "When offset is given, then should skip rows before fetch",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(Fetch{Offset: 20, Rows: 10}).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY `age` OFFSET ? ROWS FETCH FIRST ? ROWS ONLY",
+			wantArgs: []driver.Value{20, 10},
+		},
+		{
+			name:    "When only offset is given, then should skip rows only",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(Fetch{Offset: 20}).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY `age` OFFSET ? ROWS",
+			wantArgs: []driver.Value{20},
+		},
+		{
+			name:    "When with ties is given, then should fetch rows with ties",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(Fetch{Rows: 3, WithTies: true}).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY `age` FETCH FIRST ? ROWS WITH TIES",
+			wantArgs: []driver.Value{3},
+		},
+		{
+			name:    "When percent is given on oracle, then should fetch percentage of rows",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(Fetch{Rows: 10, Percent: true, WithTies: true}).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY `age` FETCH FIRST ? PERCENT ROWS WITH TIES",
+			wantArgs: []driver.Value{10},
+		},
+		{
+			name:    "When dialect is sqlserver, then should always write offset",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(NewFetch(10)).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY `age` OFFSET ? ROWS FETCH FIRST ? ROWS ONLY",
+			wantArgs: []driver.Value{0, 10},
+		},
+		{
+			name:    "When fetch is given twice, then should be used the last one",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(NewFetch(10)).Clauses(NewFetch(5)).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY `age` FETCH FIRST ? ROWS ONLY",
+			wantArgs: []driver.Value{5},
+		},
+		{
+			name:    "When used with union, then should fetch from the result of union",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("admin_users").Clauses(NewUnion(db.Table("guest_users"))).Order("`age`").Clauses(NewFetch(10)).Scan(nil)
+			},
+			want:     "SELECT * FROM `admin_users` UNION SELECT * FROM `guest_users` ORDER BY `age` FETCH FIRST ? ROWS ONLY",
+			wantArgs: []driver.Value{10},
+		},
+		{
+			name:    "When used with limit, then should be error",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Limit(5).Clauses(NewFetch(10)).Scan(nil)
+			},
+			wantErr: ErrFetchWithLimit,
+		},
+		{
+			name:    "When used with offset, then should be error",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Offset(5).Clauses(NewFetch(10)).Scan(nil)
+			},
+			wantErr: ErrFetchWithLimit,
+		},
+		{
+			name:    "When dialect is mysql, then should be error",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewFetch(10)).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When dialect is sqlite, then should be error",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewFetch(10)).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When percent is given on postgres, then should be error",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(Fetch{Rows: 10, Percent: true}).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When with ties is given on sqlserver, then should be error",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(Fetch{Rows: 10, WithTies: true}).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestFetch_Zero(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+	}{
+		{
+			name:    "When fetch is zero value, then should not write FETCH clause",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(Fetch{}).Find(&[]map[string]interface{}{})
+			},
+			want: "SELECT * FROM `users` ORDER BY `age`",
+		},
+		{
+			name:    "When zero value is given after fetch, then should remove FETCH clause",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`age`").Clauses(NewFetch(10)).Clauses(Fetch{}).Find(&[]map[string]interface{}{})
+			},
+			want: "SELECT * FROM `users` ORDER BY `age`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := openDialectDB(t, tt.dialect)
+			got, _, err := ToSQL(db, tt.operation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("SQL is %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFetch(t *testing.T) {
+	want := Fetch{Rows: 10}
+	if got := NewFetch(10); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewFetch() = %v, want %v", got, want)
+	}
+}
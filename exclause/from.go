@@ -0,0 +1,78 @@
+package exclause
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// suffixedFrom is FROM clause whose first table is followed by SystemTime and TableSample.
+// When Source is set, the first table is used as the alias of Source.
+type suffixedFrom struct {
+	From       clause.From
+	Source     clause.Expression
+	SystemTime clause.Expression
+	Sample     clause.Expression
+}
+
+// Build build FROM clause with the suffixes after the first table
+func (from suffixedFrom) Build(builder clause.Builder) {
+	if len(from.From.Tables) > 0 {
+		for index, table := range from.From.Tables {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			if index == 0 {
+				from.buildSource(builder)
+			}
+			builder.WriteQuoted(table)
+			if index == 0 {
+				from.buildSuffixes(builder)
+			}
+		}
+	} else {
+		from.buildSource(builder)
+		builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+		from.buildSuffixes(builder)
+	}
+
+	for _, join := range from.From.Joins {
+		builder.WriteByte(' ')
+		join.Build(builder)
+	}
+}
+
+func (from suffixedFrom) buildSource(builder clause.Builder) {
+	if from.Source != nil {
+		from.Source.Build(builder)
+		builder.WriteByte(' ')
+	}
+}
+
+func (from suffixedFrom) buildSuffixes(builder clause.Builder) {
+	for _, suffix := range []clause.Expression{from.SystemTime, from.Sample} {
+		if suffix != nil {
+			builder.WriteByte(' ')
+			suffix.Build(builder)
+		}
+	}
+}
+
+// modifyFrom sets the builder of FROM clause that modifies the suffixes of the first table.
+// The builder is kept until the statement is built, so it works even if the clause is given before the table.
+func modifyFrom(stmt *gorm.Statement, modify func(from *suffixedFrom)) {
+	from := stmt.Clauses["FROM"]
+	from.Name = "FROM"
+	previous := from.Builder
+	from.Builder = func(c clause.Clause, builder clause.Builder) {
+		if f, ok := c.Expression.(clause.From); ok {
+			c.Expression = suffixedFrom{From: f}
+		}
+		if f, ok := c.Expression.(suffixedFrom); ok {
+			modify(&f)
+			c.Expression = f
+		}
+		c.Builder = previous
+		c.Build(builder)
+	}
+	stmt.Clauses["FROM"] = from
+}
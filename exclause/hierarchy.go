@@ -0,0 +1,190 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HierarchyLevel is the column name of the depth of the rows queried by Hierarchy, that is 1 at the root rows
+const HierarchyLevel = "level"
+
+const hierarchyCTEName = "exclause_hierarchy"
+
+// Hierarchy is hierarchical query, that is START WITH ... CONNECT BY PRIOR ... on Oracle.
+// Other dialects query the table through WITH RECURSIVE CTE aliased as the table, so WHERE and SELECT of the statement are applied after the hierarchy is built as Oracle does.
+// The depth is exposed as HierarchyLevel column on every dialect, and it is selected with * as well.
+// OrderSiblingsBy is ORDER SIBLINGS BY on Oracle, and other dialects order the rows by HierarchyLevel and OrderSiblingsBy, that is breadth-first.
+//
+//	// examples
+//	// Oracle: SELECT `employees`.*,LEVEL AS `level` FROM `employees` START WITH `manager_id` IS NULL CONNECT BY PRIOR `id` = `manager_id` ORDER SIBLINGS BY `name`
+//	// Others: WITH RECURSIVE `exclause_hierarchy` AS (
+//	//           SELECT `employees`.*,1 AS `level` FROM `employees` WHERE `manager_id` IS NULL
+//	//           UNION ALL
+//	//           SELECT `employees`.*,`exclause_hierarchy`.`level` + 1 FROM `employees` INNER JOIN `exclause_hierarchy` ON `employees`.`manager_id` = `exclause_hierarchy`.`id`
+//	//         ) SELECT * FROM `exclause_hierarchy` `employees` ORDER BY `level`,`name`
+//	db.Table("employees").Clauses(exclause.Hierarchy{
+//		StartWith:       []clause.Expression{clause.Eq{Column: clause.Column{Name: "manager_id"}, Value: nil}},
+//		ConnectBy:       "manager_id",
+//		Prior:           "id",
+//		OrderSiblingsBy: []clause.OrderByColumn{{Column: clause.Column{Name: "name"}}},
+//	}).Scan(&employees)
+type Hierarchy struct {
+	// StartWith is the conditions of the root rows, all rows are root when it is empty
+	StartWith []clause.Expression
+	// ConnectBy is the column of the child rows that refers to Prior column of the parent row, such as parent_id
+	ConnectBy string
+	// Prior is the column of the parent row that is referred by ConnectBy column, such as id
+	Prior           string
+	OrderSiblingsBy []clause.OrderByColumn
+	// MaxLevel limits the depth of the rows, zero means unlimited
+	MaxLevel int
+}
+
+// Name hierarchy clause name
+func (hierarchy Hierarchy) Name() string {
+	return "CONNECT BY"
+}
+
+// Build build START WITH and CONNECT BY clause
+func (hierarchy Hierarchy) Build(builder clause.Builder) {
+	if len(hierarchy.StartWith) > 0 {
+		builder.WriteString("START WITH ")
+		clause.Where{Exprs: hierarchy.StartWith}.Build(builder)
+		builder.WriteByte(' ')
+	}
+	builder.WriteString("CONNECT BY PRIOR ")
+	builder.WriteQuoted(hierarchy.Prior)
+	builder.WriteString(" = ")
+	builder.WriteQuoted(hierarchy.ConnectBy)
+	if hierarchy.MaxLevel > 0 {
+		builder.WriteString(" AND LEVEL <= ")
+		builder.AddVar(builder, hierarchy.MaxLevel)
+	}
+}
+
+// MergeClause merge Hierarchy clauses, the last one is used
+func (hierarchy Hierarchy) MergeClause(mergeClause *clause.Clause) {
+	mergeClause.Name = ""
+	mergeClause.Expression = hierarchy
+}
+
+// ModifyStatement add CONNECT BY clause on Oracle, or query the table through recursive CTE on other dialects
+func (hierarchy Hierarchy) ModifyStatement(stmt *gorm.Statement) {
+	if hierarchy.Prior == "" || hierarchy.ConnectBy == "" {
+		stmt.AddError(fmt.Errorf("%w: Hierarchy needs Prior and ConnectBy", gorm.ErrInvalidData))
+		return
+	}
+
+	if statementDialect(stmt) == dialectOracle {
+		addClause(stmt, hierarchy)
+		selectLevel(stmt, clause.Expr{SQL: "LEVEL AS ?", Vars: []interface{}{clause.Column{Name: HierarchyLevel}}})
+		if len(hierarchy.OrderSiblingsBy) > 0 {
+			stmt.AddClause(clause.OrderBy{Columns: hierarchy.OrderSiblingsBy})
+			orderBy := stmt.Clauses["ORDER BY"]
+			orderBy.Builder = func(c clause.Clause, builder clause.Builder) {
+				c.Name = "ORDER SIBLINGS BY"
+				c.Builder = nil
+				c.Build(builder)
+			}
+			stmt.Clauses["ORDER BY"] = orderBy
+		}
+		return
+	}
+
+	stmt.AddClause(With{Recursive: true, CTEs: []CTE{{Name: hierarchyCTEName, Subquery: hierarchyQuery{Hierarchy: hierarchy}}}})
+	modifyFrom(stmt, func(from *suffixedFrom) {
+		from.Source = clause.Expr{SQL: "?", Vars: []interface{}{clause.Table{Name: hierarchyCTEName}}}
+	})
+	if len(hierarchy.OrderSiblingsBy) > 0 {
+		columns := append([]clause.OrderByColumn{{Column: clause.Column{Name: HierarchyLevel}}}, hierarchy.OrderSiblingsBy...)
+		stmt.AddClause(clause.OrderBy{Columns: columns})
+	}
+}
+
+// selectLevel sets the builder of SELECT clause that selects the level with all columns of the table, when no columns are selected
+func selectLevel(stmt *gorm.Statement, level clause.Expression) {
+	c := stmt.Clauses["SELECT"]
+	c.Name = "SELECT"
+	c.Builder = func(c clause.Clause, builder clause.Builder) {
+		if s, ok := c.Expression.(clause.Select); ok && len(s.Columns) == 0 && s.Expression == nil {
+			c.Expression = hierarchyColumns{Distinct: s.Distinct, Level: level}
+		}
+		c.Builder = nil
+		c.Build(builder)
+	}
+	stmt.Clauses["SELECT"] = c
+}
+
+// hierarchyColumns is all columns of the current table and the level
+type hierarchyColumns struct {
+	Distinct bool
+	Level    clause.Expression
+}
+
+// Build build the columns
+func (columns hierarchyColumns) Build(builder clause.Builder) {
+	if columns.Distinct {
+		builder.WriteString("DISTINCT ")
+	}
+	writeTableColumns(builder)
+	builder.WriteByte(',')
+	columns.Level.Build(builder)
+}
+
+// hierarchyQuery is the recursive query of Hierarchy, that is the body of the CTE
+type hierarchyQuery struct {
+	Hierarchy Hierarchy
+}
+
+// Build build the recursive query
+func (query hierarchyQuery) Build(builder clause.Builder) {
+	hierarchy := query.Hierarchy
+	cte := clause.Table{Name: hierarchyCTEName}
+
+	builder.WriteString("SELECT ")
+	writeTableColumns(builder)
+	builder.WriteString(",1 AS ")
+	builder.WriteQuoted(HierarchyLevel)
+	builder.WriteString(" FROM ")
+	writeTable(builder)
+	if len(hierarchy.StartWith) > 0 {
+		builder.WriteString(" WHERE ")
+		clause.Where{Exprs: hierarchy.StartWith}.Build(builder)
+	}
+
+	builder.WriteString(" UNION ALL SELECT ")
+	writeTableColumns(builder)
+	builder.WriteByte(',')
+	builder.WriteQuoted(clause.Column{Table: cte.Name, Name: HierarchyLevel})
+	builder.WriteString(" + 1 FROM ")
+	writeTable(builder)
+	builder.WriteString(" INNER JOIN ")
+	builder.WriteQuoted(cte)
+	builder.WriteString(" ON ")
+	writeTable(builder)
+	builder.WriteByte('.')
+	builder.WriteQuoted(hierarchy.ConnectBy)
+	builder.WriteString(" = ")
+	builder.WriteQuoted(clause.Column{Table: cte.Name, Name: hierarchy.Prior})
+	if hierarchy.MaxLevel > 0 {
+		builder.WriteString(" WHERE ")
+		builder.WriteQuoted(clause.Column{Table: cte.Name, Name: HierarchyLevel})
+		builder.WriteString(" < ")
+		builder.AddVar(builder, hierarchy.MaxLevel)
+	}
+}
+
+// writeTable writes the table name of the statement
+func writeTable(builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		builder.WriteQuoted(stmt.Table)
+	}
+}
+
+// writeTableColumns writes all columns of the table of the statement, such as `users`.*
+func writeTableColumns(builder clause.Builder) {
+	writeTable(builder)
+	builder.WriteString(".*")
+}
@@ -0,0 +1,112 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestHierarchy(t *testing.T) {
+	hierarchy := Hierarchy{
+		StartWith:       []clause.Expression{clause.Eq{Column: clause.Column{Name: "manager_id"}, Value: nil}},
+		ConnectBy:       "manager_id",
+		Prior:           "id",
+		OrderSiblingsBy: []clause.OrderByColumn{{Column: clause.Column{Name: "name"}}},
+	}
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When dialect is oracle, then should be written START WITH and CONNECT BY",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("employees").Clauses(hierarchy).Where("`department` = ?", "sales").Scan(nil)
+			},
+			want:     "SELECT `employees`.*,LEVEL AS `level` FROM `employees` WHERE `department` = ? START WITH `manager_id` IS NULL CONNECT BY PRIOR `id` = `manager_id` ORDER SIBLINGS BY `name`",
+			wantArgs: []driver.Value{"sales"},
+		},
+		{
+			name:    "When dialect is oracle and columns are selected, then should not add the level",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("employees").Clauses(Hierarchy{ConnectBy: "manager_id", Prior: "id", MaxLevel: 3}).Select("`name`", "level").Scan(nil)
+			},
+			want:     "SELECT `name`,level FROM `employees` CONNECT BY PRIOR `id` = `manager_id` AND LEVEL <= ?",
+			wantArgs: []driver.Value{3},
+		},
+		{
+			name:    "When dialect is postgres, then should query through recursive CTE",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("employees").Clauses(hierarchy).Where("`department` = ?", "sales").Scan(nil)
+			},
+			want: "WITH RECURSIVE `exclause_hierarchy` AS (" +
+				"SELECT `employees`.*,1 AS `level` FROM `employees` WHERE `manager_id` IS NULL" +
+				" UNION ALL SELECT `employees`.*,`exclause_hierarchy`.`level` + 1 FROM `employees` INNER JOIN `exclause_hierarchy` ON `employees`.`manager_id` = `exclause_hierarchy`.`id`" +
+				") SELECT * FROM `exclause_hierarchy` `employees` WHERE `department` = ? ORDER BY `level`,`name`",
+			wantArgs: []driver.Value{"sales"},
+		},
+		{
+			name:    "When dialect is sqlserver and max level is given, then should limit the recursion without RECURSIVE keyword",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(Hierarchy{ConnectBy: "manager_id", Prior: "id", MaxLevel: 3}).Table("employees").Scan(nil)
+			},
+			want: "WITH `exclause_hierarchy` AS (" +
+				"SELECT `employees`.*,1 AS `level` FROM `employees`" +
+				" UNION ALL SELECT `employees`.*,`exclause_hierarchy`.`level` + 1 FROM `employees` INNER JOIN `exclause_hierarchy` ON `employees`.`manager_id` = `exclause_hierarchy`.`id` WHERE `exclause_hierarchy`.`level` < ?" +
+				") SELECT * FROM `exclause_hierarchy` `employees`",
+			wantArgs: []driver.Value{3},
+		},
+		{
+			name:    "When statement has other CTE, then should be merged into one WITH clause",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("sales", db.Table("departments").Where("`name` = ?", "sales"))).Table("employees").
+					Clauses(Hierarchy{ConnectBy: "manager_id", Prior: "id"}).Where("`department_id` IN (SELECT `id` FROM `sales`)").Scan(nil)
+			},
+			want: "WITH RECURSIVE `sales` AS (SELECT * FROM `departments` WHERE `name` = ?),`exclause_hierarchy` AS (" +
+				"SELECT `employees`.*,1 AS `level` FROM `employees`" +
+				" UNION ALL SELECT `employees`.*,`exclause_hierarchy`.`level` + 1 FROM `employees` INNER JOIN `exclause_hierarchy` ON `employees`.`manager_id` = `exclause_hierarchy`.`id`" +
+				") SELECT * FROM `exclause_hierarchy` `employees` WHERE `department_id` IN (SELECT `id` FROM `sales`)",
+			wantArgs: []driver.Value{"sales"},
+		},
+		{
+			name:    "When ConnectBy is not given, then should be error",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("employees").Clauses(Hierarchy{Prior: "id"}).Scan(nil)
+			},
+			wantErr: gorm.ErrInvalidData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package exclause
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Hint is optimizer hints clause, that is written after SELECT, INSERT, UPDATE or DELETE keyword.
+// Hints of multiple Hint clauses and other clauses are merged into one comment.
+//
+//	// examples
+//	// SELECT /*+ MAX_EXECUTION_TIME(1000) */ * FROM `users`
+//	db.Clauses(exclause.NewHint("MAX_EXECUTION_TIME(1000)")).Table("users").Scan(&users)
+//
+//	// INSERT /*+ SET_VAR(foreign_key_checks=OFF) */ INTO `users` (`name`) VALUES ('WinterYukky')
+//	db.Clauses(exclause.NewHint("SET_VAR(foreign_key_checks=OFF)")).Create(&User{Name: "WinterYukky"})
+//
+//	// UPDATE /*+ INDEX(users idx_name) NO_ICP(users) */ `users` SET `age`=20 WHERE `name` = 'WinterYukky'
+//	db.Clauses(exclause.NewHint("INDEX(users idx_name)", "NO_ICP(users)")).Table("users").Where("`name` = ?", "WinterYukky").Update("age", 20)
+//
+//	// WITH `cte` AS (SELECT /*+ INDEX(users idx_name) */ * FROM `users`) SELECT * FROM `cte`
+//	db.Clauses(exclause.NewWith("cte", db.Clauses(exclause.NewHint("INDEX(users idx_name)")).Table("users"))).Table("cte").Scan(&users)
+type Hint struct {
+	Hints []string
+}
+
+// Build build hint comment, when Hint is used as an expression such as in raw SQL
+func (hint Hint) Build(builder clause.Builder) {
+	optimizerHints{Hints: hint.expressions()}.Build(builder)
+}
+
+// ModifyStatement add hints after the statement keyword, that are merged with the hints of other clauses
+func (hint Hint) ModifyStatement(stmt *gorm.Statement) {
+	addOptimizerHints(stmt, hint.expressions()...)
+}
+
+func (hint Hint) expressions() []clause.Expression {
+	exprs := make([]clause.Expression, len(hint.Hints))
+	for index, h := range hint.Hints {
+		exprs[index] = clause.Expr{SQL: h}
+	}
+	return exprs
+}
+
+// NewHint is easy to create new Hint
+//
+//	// examples
+//	// SELECT /*+ MAX_EXECUTION_TIME(1000) */ * FROM `users`
+//	db.Clauses(exclause.NewHint("MAX_EXECUTION_TIME(1000)")).Table("users").Scan(&users)
+func NewHint(hints ...string) Hint {
+	return Hint{Hints: hints}
+}
@@ -0,0 +1,116 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestHint(t *testing.T) {
+	tests := []struct {
+		name      string
+		exec      bool
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "When query has Hint, then should be written after SELECT",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewHint("MAX_EXECUTION_TIME(1000)")).Table("users").Where("`name` = ?", "WinterYukky").Scan(nil)
+			},
+			want:     "SELECT /*+ MAX_EXECUTION_TIME(1000) */ * FROM `users` WHERE `name` = ?",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When query has multiple Hint, then should be merged into one comment",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewHint("MAX_EXECUTION_TIME(1000)")).Clauses(NewHint("INDEX(users idx_name)", "NO_ICP(users)")).Table("users").Distinct("name").Scan(nil)
+			},
+			want:     "SELECT /*+ MAX_EXECUTION_TIME(1000) INDEX(users idx_name) NO_ICP(users) */ DISTINCT name FROM `users`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When query has Hint and materialized CTE, then should be merged into one comment",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", db.Table("users"))}}).Clauses(NewHint("MAX_EXECUTION_TIME(1000)")).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users`) SELECT /*+ NO_MERGE(`cte`) MAX_EXECUTION_TIME(1000) */ * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When CTE subquery has Hint, then should be written in the subquery",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Clauses(NewHint("INDEX(users idx_name)")).Table("users"))).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT /*+ INDEX(users idx_name) */ * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When create has Hint, then should be written after INSERT",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewHint("SET_VAR(foreign_key_checks=OFF)")).Table("users").Create(map[string]interface{}{"name": "WinterYukky"})
+			},
+			want:     "INSERT /*+ SET_VAR(foreign_key_checks=OFF) */ INTO `users` (`name`) VALUES (?)",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When create has Hint and insert modifier, then should be written before the modifier",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewHint("SET_VAR(foreign_key_checks=OFF)"), NewInsertModifier(InsertIgnore)).Table("users").Create(map[string]interface{}{"name": "WinterYukky"})
+			},
+			want:     "INSERT /*+ SET_VAR(foreign_key_checks=OFF) */ IGNORE INTO `users` (`name`) VALUES (?)",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When update has Hint, then should be written after UPDATE",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewHint("NO_ICP(users)")).Table("users").Where("`name` = ?", "WinterYukky").Update("age", 20)
+			},
+			want:     "UPDATE /*+ NO_ICP(users) */ `users` SET `age`=? WHERE `name` = ?",
+			wantArgs: []driver.Value{20, "WinterYukky"},
+		},
+		{
+			name: "When delete has Hint, then should be written after DELETE",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewHint("NO_ICP(users)")).Table("users").Where("`name` = ?", "WinterYukky").Delete(nil)
+			},
+			want:     "DELETE /*+ NO_ICP(users) */ FROM `users` WHERE `name` = ?",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, dialectMySQL)
+			if tt.exec {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewHint(t *testing.T) {
+	want := Hint{Hints: []string{"MAX_EXECUTION_TIME(1000)", "NO_ICP(users)"}}
+	if got := NewHint("MAX_EXECUTION_TIME(1000)", "NO_ICP(users)"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewHint() = %v, want %v", got, want)
+	}
+}
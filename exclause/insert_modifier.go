@@ -0,0 +1,126 @@
+package exclause
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsertMode is the behavior of INSERT when the row conflicts with unique keys
+type InsertMode int
+
+const (
+	// InsertModeUnspecified means plain INSERT
+	InsertModeUnspecified InsertMode = iota
+	// InsertIgnore skips conflicting rows
+	InsertIgnore
+	// InsertReplace deletes conflicting rows and inserts new rows
+	InsertReplace
+	// InsertAbort aborts the statement on conflicts, it is default behavior of databases
+	InsertAbort
+)
+
+// InsertModifier modifies INSERT keyword of the create statement for the InsertMode.
+// It is INSERT IGNORE and REPLACE on MySQL, INSERT OR IGNORE, INSERT OR REPLACE and INSERT OR ABORT on SQLite,
+// and InsertIgnore is ON CONFLICT DO NOTHING on PostgreSQL. Other combinations are ErrUnsupportedDialect.
+//
+//	// examples
+//	// MySQL:      INSERT IGNORE INTO `users` (`name`) VALUES ('WinterYukky')
+//	// SQLite:     INSERT OR IGNORE INTO `users` (`name`) VALUES ('WinterYukky')
+//	// PostgreSQL: INSERT INTO `users` (`name`) VALUES ('WinterYukky') ON CONFLICT DO NOTHING
+//	db.Clauses(exclause.NewInsertModifier(exclause.InsertIgnore)).Create(&user)
+//
+//	// MySQL:  REPLACE INTO `users` (`id`,`name`) VALUES (1,'WinterYukky')
+//	// SQLite: INSERT OR REPLACE INTO `users` (`id`,`name`) VALUES (1,'WinterYukky')
+//	db.Clauses(exclause.NewInsertModifier(exclause.InsertReplace)).Create(&user)
+type InsertModifier struct {
+	Mode InsertMode
+}
+
+var (
+	mysqlInsertModifiers = map[InsertMode]string{
+		InsertIgnore: "INSERT IGNORE",
+		InsertAbort:  "INSERT",
+	}
+	sqliteInsertModifiers = map[InsertMode]string{
+		InsertIgnore:  "INSERT OR IGNORE",
+		InsertReplace: "INSERT OR REPLACE",
+		InsertAbort:   "INSERT OR ABORT",
+	}
+)
+
+// Name insert modifier clause name
+func (modifier InsertModifier) Name() string {
+	return "INSERT"
+}
+
+// Build build the INSERT keyword with the modifier
+func (modifier InsertModifier) Build(builder clause.Builder) {
+	keyword, err := modifier.keyword(dialectOf(builder))
+	if err != nil {
+		builder.AddError(err)
+		return
+	}
+	builder.WriteString(keyword)
+}
+
+// MergeClause merge InsertModifier clauses, the last one is used
+func (modifier InsertModifier) MergeClause(mergeClause *clause.Clause) {
+	mergeClause.Expression = modifier
+}
+
+// ModifyStatement modifies INSERT clause of the statement, or adds ON CONFLICT DO NOTHING on PostgreSQL
+func (modifier InsertModifier) ModifyStatement(stmt *gorm.Statement) {
+	dialect := statementDialect(stmt)
+	if dialect == dialectPostgres && modifier.Mode == InsertIgnore {
+		stmt.AddClause(clause.OnConflict{DoNothing: true})
+		return
+	}
+	keyword, err := modifier.keyword(dialect)
+	if err != nil {
+		stmt.AddError(err)
+		return
+	}
+
+	c := stmt.Clauses["INSERT"]
+	insert, _ := c.Expression.(clause.Insert)
+	// clause.Insert is written as "<Name> <Modifier> INTO <Table>"
+	c.Name, insert.Modifier, _ = strings.Cut(keyword, " ")
+	c.Expression = insert
+	stmt.Clauses["INSERT"] = c
+}
+
+func (modifier InsertModifier) keyword(dialect string) (string, error) {
+	if modifier.Mode == InsertModeUnspecified {
+		return "INSERT", nil
+	}
+	var keyword string
+	switch dialect {
+	case dialectMySQL:
+		keyword = mysqlInsertModifiers[modifier.Mode]
+		if modifier.Mode == InsertReplace {
+			keyword = "REPLACE"
+		}
+	case dialectSQLite:
+		keyword = sqliteInsertModifiers[modifier.Mode]
+	default:
+		if modifier.Mode == InsertAbort {
+			keyword = "INSERT"
+		}
+	}
+	if keyword == "" {
+		return "", fmt.Errorf("%w: insert mode %d on %s", ErrUnsupportedDialect, modifier.Mode, dialect)
+	}
+	return keyword, nil
+}
+
+// NewInsertModifier is easy to create new InsertModifier
+//
+//	// examples
+//	// INSERT IGNORE INTO `users` (`name`) VALUES ('WinterYukky')
+//	db.Clauses(exclause.NewInsertModifier(exclause.InsertIgnore)).Create(&user)
+func NewInsertModifier(mode InsertMode) InsertModifier {
+	return InsertModifier{Mode: mode}
+}
@@ -0,0 +1,140 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type insertUser struct {
+	ID   uint
+	Name string
+}
+
+func TestInsertModifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When mode is ignore on mysql, then should be INSERT IGNORE",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertModifier(InsertIgnore)).Create(&insertUser{Name: "WinterYukky"})
+			},
+			want:     "INSERT IGNORE INTO `insert_users` (`name`) VALUES (?)",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name:    "When mode is replace on mysql, then should be REPLACE",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertModifier(InsertReplace)).Table("users").Create(map[string]interface{}{"id": 1, "name": "WinterYukky"})
+			},
+			want:     "REPLACE INTO `users` (`id`,`name`) VALUES (?,?)",
+			wantArgs: []driver.Value{1, "WinterYukky"},
+		},
+		{
+			name:    "When mode is ignore with comment, then should be written after comment",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("import"), NewInsertModifier(InsertIgnore)).Create(&insertUser{Name: "WinterYukky"})
+			},
+			want:     "/* import */ INSERT IGNORE INTO `insert_users` (`name`) VALUES (?)",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name:    "When mode is replace on sqlite, then should be INSERT OR REPLACE",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertModifier(InsertReplace)).Create(&insertUser{ID: 1, Name: "WinterYukky"})
+			},
+			want:     "INSERT OR REPLACE INTO `insert_users` (`name`,`id`) VALUES (?,?)",
+			wantArgs: []driver.Value{"WinterYukky", 1},
+		},
+		{
+			name:    "When mode is abort on sqlite, then should be INSERT OR ABORT",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertModifier(InsertAbort)).Create(&insertUser{Name: "WinterYukky"})
+			},
+			want:     "INSERT OR ABORT INTO `insert_users` (`name`) VALUES (?)",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name:    "When mode is abort on sqlserver, then should be plain INSERT",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertModifier(InsertAbort)).Create(&insertUser{Name: "WinterYukky"})
+			},
+			want:     "INSERT INTO `insert_users` (`name`) VALUES (?)",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name:    "When mode is replace on postgres, then should be error",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertModifier(InsertReplace)).Create(&insertUser{Name: "WinterYukky"})
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When mode is ignore on oracle, then should be error",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertModifier(InsertIgnore)).Create(&insertUser{Name: "WinterYukky"})
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestInsertModifier_Postgres(t *testing.T) {
+	db, _ := openDialectDB(t, dialectPostgres)
+	stmt := db.Clauses(NewInsertModifier(InsertIgnore)).Statement
+	want := clause.OnConflict{DoNothing: true}
+	if got := stmt.Clauses["ON CONFLICT"].Expression; !reflect.DeepEqual(got, want) {
+		t.Errorf("ON CONFLICT = %v, want %v", got, want)
+	}
+	if _, ok := stmt.Clauses["INSERT"]; ok {
+		t.Errorf("INSERT clause is modified, want plain INSERT")
+	}
+}
+
+func TestNewInsertModifier(t *testing.T) {
+	want := InsertModifier{Mode: InsertIgnore}
+	if got := NewInsertModifier(InsertIgnore); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewInsertModifier() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package exclause
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsertSelect is INSERT ... SELECT source of the create statement, that is written instead of VALUES of the created model.
+// The statement is created with Create(nil), as the rows are selected by the subquery.
+// MySQL and Oracle don't accept WITH before INSERT, so WITH clause of the statement is written before the SELECT there.
+//
+//	// examples
+//	// INSERT INTO `users` (`name`) SELECT `name` FROM `admins`
+//	db.Clauses(exclause.NewInsertSelect([]string{"name"}, db.Table("admins").Select("name"))).Table("users").Create(nil)
+//
+//	// PostgreSQL: WITH `cte` AS (SELECT * FROM `admins`) INSERT INTO `users` (`name`) SELECT `name` FROM `cte`
+//	// MySQL:      INSERT INTO `users` (`name`) WITH `cte` AS (SELECT * FROM `admins`) SELECT `name` FROM `cte`
+//	db.Clauses(exclause.NewWith("cte", db.Table("admins"))).
+//		Clauses(exclause.NewInsertSelect([]string{"name"}, "SELECT `name` FROM `cte`")).Table("users").Create(nil)
+type InsertSelect struct {
+	Columns  []string
+	Subquery clause.Expression
+	// with is WITH clause of the statement, that is written before the SELECT on MySQL and Oracle
+	with With
+}
+
+// Name insert select clause name
+func (insertSelect InsertSelect) Name() string {
+	return "INSERT SELECT"
+}
+
+// Build build insert select clause
+func (insertSelect InsertSelect) Build(builder clause.Builder) {
+	if len(insertSelect.Columns) > 0 {
+		builder.WriteByte('(')
+		for index, column := range insertSelect.Columns {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(column)
+		}
+		builder.WriteString(") ")
+	}
+	if len(insertSelect.with.CTEs) > 0 {
+		builder.WriteString("WITH ")
+		insertSelect.with.Build(builder)
+		builder.WriteByte(' ')
+	}
+	insertSelect.Subquery.Build(builder)
+}
+
+// MergeClause merge InsertSelect clauses, the last one is used with WITH clause moved into the former one
+func (insertSelect InsertSelect) MergeClause(mergeClause *clause.Clause) {
+	if s, ok := mergeClause.Expression.(InsertSelect); ok {
+		insertSelect.with = s.with
+	}
+
+	mergeClause.Name = ""
+	mergeClause.Expression = insertSelect
+}
+
+// ModifyStatement add InsertSelect clause to the statement, and moves WITH clause into it on MySQL and Oracle
+func (insertSelect InsertSelect) ModifyStatement(stmt *gorm.Statement) {
+	addClause(stmt, insertSelect)
+	moveWithToInsertSelect(stmt)
+}
+
+// moveWithToInsertSelect moves WITH clause of the create statement before the SELECT of InsertSelect on MySQL and Oracle
+func moveWithToInsertSelect(stmt *gorm.Statement) {
+	if dialect := statementDialect(stmt); dialect != dialectMySQL && dialect != dialectOracle {
+		return
+	}
+	c, ok := stmt.Clauses["INSERT SELECT"]
+	if !ok {
+		return
+	}
+	with, ok := stmt.Clauses["WITH"]
+	if !ok {
+		return
+	}
+	insertSelect, ok := c.Expression.(InsertSelect)
+	if !ok {
+		return
+	}
+	if w, ok := with.Expression.(With); ok {
+		merged := clause.Clause{Expression: insertSelect.with}
+		w.MergeClause(&merged)
+		insertSelect.with = merged.Expression.(With)
+	}
+	c.Expression = insertSelect
+	stmt.Clauses["INSERT SELECT"] = c
+	delete(stmt.Clauses, "WITH")
+}
+
+// NewInsertSelect is easy to create new InsertSelect.
+// The subquery can be *gorm.DB, raw SQL string or clause.Expression.
+//
+//	// examples
+//	// INSERT INTO `users` (`name`) SELECT `name` FROM `admins` WHERE `active` = true
+//	db.Clauses(exclause.NewInsertSelect([]string{"name"}, "SELECT `name` FROM `admins` WHERE `active` = ?", true)).Table("users").Create(nil)
+func NewInsertSelect(columns []string, subquery interface{}, args ...interface{}) InsertSelect {
+	return InsertSelect{
+		Columns:  columns,
+		Subquery: convertToClauseExpression(subquery, args...),
+	}
+}
@@ -0,0 +1,106 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestInsertSelect_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name:    "When subquery is given, then should be inserted by SELECT",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertSelect([]string{"name"}, db.Table("admins").Select("`name`").Where("`active` = ?", true))).Table("users").Create(nil)
+			},
+			want:     "INSERT INTO `users` (`name`) SELECT `name` FROM `admins` WHERE `active` = ?",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When statement has WITH clause on postgres, then should be written before INSERT",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("admins"))).
+					Clauses(NewInsertSelect([]string{"name"}, "SELECT `name` FROM `cte`")).Table("users").Create(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `admins`) INSERT INTO `users` (`name`) SELECT `name` FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When statement has WITH clause and insert modifier on sqlite, then should be written before INSERT OR IGNORE",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("admins").Where("`active` = ?", true)), NewInsertModifier(InsertIgnore)).
+					Clauses(NewInsertSelect([]string{"id", "name"}, "SELECT `id`,`name` FROM `cte`")).Table("users").Create(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `admins` WHERE `active` = ?) INSERT OR IGNORE INTO `users` (`id`,`name`) SELECT `id`,`name` FROM `cte`",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When statement has WITH clause and insert modifier on mysql, then should be written before SELECT",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("admins").Where("`active` = ?", true)), NewInsertModifier(InsertIgnore)).
+					Clauses(NewInsertSelect([]string{"name"}, "SELECT `name` FROM `cte`")).Table("users").Create(nil)
+			},
+			want:     "INSERT IGNORE INTO `users` (`name`) WITH `cte` AS (SELECT * FROM `admins` WHERE `active` = ?) SELECT `name` FROM `cte`",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When WITH clause is given after InsertSelect on oracle, then should be written before SELECT",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewInsertSelect([]string{"name"}, "SELECT `name` FROM `cte`")).
+					Clauses(NewWith("cte", db.Table("admins"))).Table("users").Create(nil)
+			},
+			want:     "INSERT INTO `users` (`name`) WITH `cte` AS (SELECT * FROM `admins`) SELECT `name` FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When columns are not given, then should be inserted to all columns",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewComment("copy"), NewInsertSelect(nil, db.Table("admins"))).Table("users").Create(nil)
+			},
+			want:     "/* copy */ INSERT INTO `users` SELECT * FROM `admins`",
+			wantArgs: []driver.Value{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewInsertSelect(t *testing.T) {
+	got := NewInsertSelect([]string{"name"}, "SELECT `name` FROM `admins` WHERE `active` = ?", true)
+	want := InsertSelect{Columns: []string{"name"}, Subquery: clause.Expr{SQL: "SELECT `name` FROM `admins` WHERE `active` = ?", Vars: []interface{}{true}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewInsertSelect() = %v, want %v", got, want)
+	}
+}
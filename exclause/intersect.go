@@ -8,6 +8,10 @@ import (
 // Intersect is intersect clause
 type Intersect struct {
 	Statements []clause.Expression
+	// EmulateOn are the columns compared to emulate INTERSECT with EXISTS,
+	// for databases that don't support INTERSECT such as MySQL before 8.0.31.
+	// The statements must be plain queries, and duplicated rows are kept unless Distinct is used.
+	EmulateOn []string
 }
 
 // Name intersect clause name
@@ -37,6 +41,15 @@ func (intersect Intersect) MergeClause(mergeClause *clause.Clause) {
 	mergeClause.Expression = intersect
 }
 
+// ModifyStatement add Intersect clause to the statement, or EXISTS conditions when EmulateOn is set
+func (intersect Intersect) ModifyStatement(stmt *gorm.Statement) {
+	if len(intersect.EmulateOn) == 0 {
+		addClause(stmt, intersect)
+		return
+	}
+	emulateSetOperation(stmt, false, intersect.EmulateOn, intersect.Statements)
+}
+
 // NewIntersect is easy to create new Intersect
 //
 //	// examples
@@ -48,6 +61,11 @@ func (intersect Intersect) MergeClause(mergeClause *clause.Clause) {
 //
 //	// SELECT * FROM `general_users` INTERSECT ALL SELECT * FROM `admin_users`
 //	db.Table("general_users").Clauses(exclause.NewIntersect("ALL ?", db.Table("admin_users"))).Scan(&users)
+//
+//	// SELECT * FROM `general_users` WHERE EXISTS (SELECT 1 FROM (SELECT * FROM `admin_users`) AS `exclause_right` WHERE `general_users`.`id` <=> `exclause_right`.`id`)
+//	intersect := exclause.NewIntersect(db.Table("admin_users"))
+//	intersect.EmulateOn = []string{"id"}
+//	db.Table("general_users").Clauses(intersect).Scan(&users)
 func NewIntersect(query interface{}, args ...interface{}) Intersect {
 	switch v := query.(type) {
 	case *gorm.DB:
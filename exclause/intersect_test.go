@@ -99,6 +99,56 @@ func TestIntersect_Query(t *testing.T) {
 	}
 }
 
+func TestIntersect_Emulation(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name:    "When EmulateOn is set, then should be used EXISTS",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("general_users").
+					Clauses(Intersect{
+						Statements: []clause.Expression{Subquery{DB: db.Table("admin_users")}},
+						EmulateOn:  []string{"id"},
+					}).Scan(nil)
+			},
+			want:     "SELECT * FROM `general_users` WHERE EXISTS (SELECT 1 FROM (SELECT * FROM `admin_users`) AS `exclause_right` WHERE `general_users`.`id` <=> `exclause_right`.`id`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When EmulateOn is set with multiple statements, then should be used EXISTS for each statement",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("general_users").
+					Clauses(Intersect{
+						Statements: []clause.Expression{Subquery{DB: db.Table("admin_users")}, Subquery{DB: db.Table("guest_users")}},
+						EmulateOn:  []string{"id"},
+					}).Scan(nil)
+			},
+			want: "SELECT * FROM `general_users` WHERE EXISTS (SELECT 1 FROM (SELECT * FROM `admin_users`) AS `exclause_right` WHERE `general_users`.`id` IS NOT DISTINCT FROM `exclause_right`.`id`) " +
+				"AND EXISTS (SELECT 1 FROM (SELECT * FROM `guest_users`) AS `exclause_right` WHERE `general_users`.`id` IS NOT DISTINCT FROM `exclause_right`.`id`)",
+			wantArgs: []driver.Value{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+		})
+	}
+}
+
 func TestNewIntersect(t *testing.T) {
 	mockDB, _, err := sqlmock.New()
 	if err != nil {
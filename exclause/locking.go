@@ -0,0 +1,76 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm/clause"
+)
+
+// Locking strengths of PostgreSQL in addition to clause.LockingStrengthUpdate and clause.LockingStrengthShare
+const (
+	// LockingStrengthNoKeyUpdate locks rows like FOR UPDATE, but doesn't block FOR KEY SHARE of foreign keys
+	LockingStrengthNoKeyUpdate = "NO KEY UPDATE"
+	// LockingStrengthKeyShare locks rows like FOR SHARE, but only blocks changes of the keys
+	LockingStrengthKeyShare = "KEY SHARE"
+)
+
+// Locking is row locking clause that has multiple locks, such as different strength per table.
+// clause.Locking given to the statement before is merged into it.
+// NO KEY UPDATE and KEY SHARE strengths are PostgreSQL only.
+//
+//	// examples
+//	// SELECT * FROM `users` JOIN `groups` ON ... FOR NO KEY UPDATE OF `users` FOR KEY SHARE OF `groups` NOWAIT
+//	db.Table("users").Joins("JOIN `groups` ON ...").Clauses(exclause.NewLocking(
+//		clause.Locking{Strength: exclause.LockingStrengthNoKeyUpdate, Table: clause.Table{Name: "users"}},
+//		clause.Locking{Strength: exclause.LockingStrengthKeyShare, Table: clause.Table{Name: "groups"}, Options: clause.LockingOptionsNoWait},
+//	)).Scan(&users)
+//
+//	// WITH `cte` AS (SELECT * FROM `users` FOR UPDATE SKIP LOCKED) SELECT * FROM `cte`
+//	db.Clauses(exclause.NewWith("cte", db.Table("users").Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}))).Table("cte").Scan(&users)
+type Locking struct {
+	Locks []clause.Locking
+}
+
+// Name locking clause name
+func (locking Locking) Name() string {
+	return "FOR"
+}
+
+// Build build locking clause
+func (locking Locking) Build(builder clause.Builder) {
+	dialect := dialectOf(builder)
+	for index, lock := range locking.Locks {
+		if (lock.Strength == LockingStrengthNoKeyUpdate || lock.Strength == LockingStrengthKeyShare) && dialect != "" && dialect != dialectPostgres {
+			builder.AddError(fmt.Errorf("%w: FOR %s on %s", ErrUnsupportedDialect, lock.Strength, dialect))
+			return
+		}
+		if index > 0 {
+			builder.WriteString(" FOR ")
+		}
+		lock.Build(builder)
+	}
+}
+
+// MergeClause merge Locking clauses
+func (locking Locking) MergeClause(mergeClause *clause.Clause) {
+	switch l := mergeClause.Expression.(type) {
+	case clause.Locking:
+		locking.Locks = append([]clause.Locking{l}, locking.Locks...)
+	case Locking:
+		locks := make([]clause.Locking, len(l.Locks)+len(locking.Locks))
+		copy(locks, l.Locks)
+		copy(locks[len(l.Locks):], locking.Locks)
+		locking.Locks = locks
+	}
+
+	mergeClause.Expression = locking
+}
+
+// NewLocking is easy to create new Locking
+//
+//	// examples
+//	// SELECT * FROM `users` FOR UPDATE OF `users` SKIP LOCKED
+//	db.Table("users").Clauses(exclause.NewLocking(clause.Locking{Strength: clause.LockingStrengthUpdate, Table: clause.Table{Name: "users"}, Options: clause.LockingOptionsSkipLocked})).Scan(&users)
+func NewLocking(locks ...clause.Locking) Locking {
+	return Locking{Locks: locks}
+}
@@ -0,0 +1,107 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestLocking(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When locks are given per table, then should be written each FOR",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Joins("JOIN `groups` ON `groups`.`id` = `users`.`group_id`").Clauses(NewLocking(
+					clause.Locking{Strength: LockingStrengthNoKeyUpdate, Table: clause.Table{Name: "users"}},
+					clause.Locking{Strength: LockingStrengthKeyShare, Table: clause.Table{Name: "groups"}, Options: clause.LockingOptionsNoWait},
+				)).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` JOIN `groups` ON `groups`.`id` = `users`.`group_id` FOR NO KEY UPDATE OF `users` FOR KEY SHARE OF `groups` NOWAIT",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When clause.Locking is given before, then should be merged",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").
+					Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Table: clause.Table{Name: "users"}}).
+					Clauses(NewLocking(clause.Locking{Strength: clause.LockingStrengthShare, Table: clause.Table{Name: "groups"}})).
+					Clauses(NewLocking(clause.Locking{Strength: clause.LockingStrengthShare, Table: clause.Table{Name: "roles"}, Options: clause.LockingOptionsSkipLocked})).
+					Scan(nil)
+			},
+			want:     "SELECT * FROM `users` FOR UPDATE OF `users` FOR SHARE OF `groups` FOR SHARE OF `roles` SKIP LOCKED",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When CTE subquery has locking, then should be written in the subquery",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("users").Where("`name` = ?", "WinterYukky").Clauses(
+					clause.Locking{Strength: LockingStrengthNoKeyUpdate, Options: clause.LockingOptionsSkipLocked},
+				))).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` WHERE `name` = ? FOR NO KEY UPDATE SKIP LOCKED) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name:    "When CTE subquery and query have locking, then should be written in each",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("users").Clauses(
+					clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsNoWait},
+				))).Table("cte").Clauses(NewLocking(clause.Locking{Strength: LockingStrengthKeyShare})).Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` FOR UPDATE NOWAIT) SELECT * FROM `cte` FOR KEY SHARE",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When NO KEY UPDATE is given on mysql, then should be error",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewLocking(clause.Locking{Strength: LockingStrengthNoKeyUpdate})).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewLocking(t *testing.T) {
+	lock := clause.Locking{Strength: clause.LockingStrengthUpdate}
+	want := Locking{Locks: []clause.Locking{lock}}
+	if got := NewLocking(lock); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewLocking() = %v, want %v", got, want)
+	}
+}
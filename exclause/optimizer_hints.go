@@ -0,0 +1,60 @@
+package exclause
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// optimizerHints is an optimizer hint comment such as /*+ NO_MERGE(`cte`) */ written after the statement keyword.
+// Hints added by multiple clauses are merged into one comment, as the databases read only the first one.
+type optimizerHints struct {
+	Hints []clause.Expression
+}
+
+// Build build optimizer hints
+func (hints optimizerHints) Build(builder clause.Builder) {
+	builder.WriteString("/*+ ")
+	for index, hint := range hints.Hints {
+		if index > 0 {
+			builder.WriteByte(' ')
+		}
+		hint.Build(builder)
+	}
+	builder.WriteString(" */")
+}
+
+// addOptimizerHints adds hints after SELECT, INSERT, UPDATE and DELETE keywords of the statement.
+// The statement type isn't known when clauses are added, so all of them are hinted.
+func addOptimizerHints(stmt *gorm.Statement, hints ...clause.Expression) {
+	for _, name := range []string{"SELECT", "INSERT", "UPDATE", "DELETE"} {
+		c := stmt.Clauses[name]
+		// clause.Delete writes DELETE keyword by itself, so it is hinted after the expression
+		if name == "DELETE" {
+			c.AfterExpression = mergeOptimizerHints(c.AfterExpression, hints)
+		} else {
+			c.AfterNameExpression = mergeOptimizerHints(c.AfterNameExpression, hints)
+		}
+		stmt.Clauses[name] = c
+	}
+}
+
+// mergeOptimizerHints appends hints that aren't in the current optimizer hints
+func mergeOptimizerHints(current clause.Expression, hints []clause.Expression) clause.Expression {
+	merged, _ := current.(optimizerHints)
+	merged.Hints = append([]clause.Expression(nil), merged.Hints...)
+	for _, hint := range hints {
+		exists := false
+		for _, h := range merged.Hints {
+			if reflect.DeepEqual(h, hint) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			merged.Hints = append(merged.Hints, hint)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,199 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Sources of OutputColumn
+const (
+	// OutputInserted refers to the rows after INSERT or UPDATE
+	OutputInserted = "INSERTED"
+	// OutputDeleted refers to the rows before UPDATE or DELETE
+	OutputDeleted = "DELETED"
+)
+
+// Output is SQL Server OUTPUT clause, that returns the affected rows like RETURNING.
+// It is written between the column list and VALUES of INSERT, after SET of UPDATE and after FROM of DELETE,
+// or between the target table and FROM of DELETE with DeleteUsing, and the results are scanned back into the model when ExtraClausePlugin is installed.
+// Output is ErrUnsupportedDialect on other dialects, use clause.Returning instead.
+//
+//	// examples
+//	// INSERT INTO `users` (`name`) OUTPUT INSERTED.`id` VALUES ('WinterYukky')
+//	db.Clauses(exclause.NewOutput(exclause.Inserted("id"))).Create(&user)
+//
+//	// UPDATE `users` SET `name`='Yukky' OUTPUT INSERTED.* WHERE `id` = 1
+//	db.Clauses(exclause.NewOutput(exclause.Inserted("*"))).Model(&user).Update("name", "Yukky")
+//
+//	// DELETE FROM `users` OUTPUT DELETED.`id`,DELETED.`name` WHERE `name` = 'WinterYukky'
+//	db.Clauses(exclause.NewOutput(exclause.Deleted("id"), exclause.Deleted("name"))).Where("`name` = ?", "WinterYukky").Delete(&users)
+type Output struct {
+	Columns []OutputColumn
+}
+
+// OutputColumn is a column reference of Output
+type OutputColumn struct {
+	// Source is OutputInserted or OutputDeleted
+	Source string
+	// Name is the column name, or * for all columns
+	Name string
+	// Alias is the name of the output column, it is used to scan the result into the model
+	Alias string
+}
+
+// Inserted is easy to create new OutputColumn refers to INSERTED
+//
+//	// examples
+//	// INSERTED.`id`
+//	exclause.Inserted("id")
+func Inserted(name string) OutputColumn {
+	return OutputColumn{Source: OutputInserted, Name: name}
+}
+
+// Deleted is easy to create new OutputColumn refers to DELETED
+//
+//	// examples
+//	// DELETED.`id`
+//	exclause.Deleted("id")
+func Deleted(name string) OutputColumn {
+	return OutputColumn{Source: OutputDeleted, Name: name}
+}
+
+// As returns the output column aliased
+//
+//	// examples
+//	// DELETED.`name` AS `old_name`
+//	exclause.Deleted("name").As("old_name")
+func (column OutputColumn) As(alias string) OutputColumn {
+	column.Alias = alias
+	return column
+}
+
+// Build build output column
+func (column OutputColumn) Build(builder clause.Builder) {
+	builder.WriteString(column.Source)
+	builder.WriteByte('.')
+	if column.Name == "*" {
+		builder.WriteByte('*')
+	} else {
+		builder.WriteQuoted(column.Name)
+	}
+	if column.Alias != "" {
+		builder.WriteString(" AS ")
+		builder.WriteQuoted(column.Alias)
+	}
+}
+
+// Name output clause name
+func (output Output) Name() string {
+	return "OUTPUT"
+}
+
+// Build build output clause
+func (output Output) Build(builder clause.Builder) {
+	if dialect := dialectOf(builder); dialect != dialectSQLServer {
+		builder.AddError(fmt.Errorf("%w: OUTPUT on %s", ErrUnsupportedDialect, dialect))
+		return
+	}
+	builder.WriteString("OUTPUT ")
+	if len(output.Columns) == 0 {
+		builder.WriteString(OutputInserted + ".*")
+		return
+	}
+	for index, column := range output.Columns {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+		column.Build(builder)
+	}
+}
+
+// MergeClause merge Output clauses
+func (output Output) MergeClause(mergeClause *clause.Clause) {
+	if o, ok := mergeClause.Expression.(Output); ok {
+		columns := make([]OutputColumn, len(o.Columns)+len(output.Columns))
+		copy(columns, o.Columns)
+		copy(columns[len(o.Columns):], output.Columns)
+		output.Columns = columns
+	}
+	mergeClause.Name = ""
+	mergeClause.Expression = output
+}
+
+// ModifyStatement add output clause, RETURNING clause to scan the results, and writes output clause in VALUES clause of INSERT
+func (output Output) ModifyStatement(stmt *gorm.Statement) {
+	if dialect := statementDialect(stmt); dialect != dialectSQLServer {
+		stmt.AddError(fmt.Errorf("%w: OUTPUT on %s", ErrUnsupportedDialect, dialect))
+		return
+	}
+	addClause(stmt, output)
+	stmt.AddClause(output.returning())
+
+	values := stmt.Clauses["VALUES"]
+	values.Name = "VALUES"
+	values.Builder = buildOutputValues
+	stmt.Clauses["VALUES"] = values
+}
+
+// returning is clause.Returning of the output columns, that is not written but used by gorm callbacks to scan the results
+func (output Output) returning() clause.Returning {
+	returning := clause.Returning{}
+	for _, column := range output.Columns {
+		if column.Name == "*" {
+			return clause.Returning{}
+		}
+		name := column.Name
+		if column.Alias != "" {
+			name = column.Alias
+		}
+		returning.Columns = append(returning.Columns, clause.Column{Name: name})
+	}
+	return returning
+}
+
+// buildOutputValues build VALUES clause with OUTPUT clause between the column list and VALUES
+func buildOutputValues(c clause.Clause, builder clause.Builder) {
+	values, ok := c.Expression.(clause.Values)
+	stmt, isStatement := builder.(*gorm.Statement)
+	if !ok || !isStatement {
+		c.Builder = nil
+		c.Build(builder)
+		return
+	}
+	output, _ := stmt.Clauses["OUTPUT"].Expression.(Output)
+	if len(values.Columns) == 0 {
+		output.Build(builder)
+		builder.WriteString(" DEFAULT VALUES")
+		return
+	}
+
+	builder.WriteByte('(')
+	for index, column := range values.Columns {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(column)
+	}
+	builder.WriteString(") ")
+	output.Build(builder)
+	builder.WriteString(" VALUES ")
+	for index, value := range values.Values {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteByte('(')
+		builder.AddVar(builder, value...)
+		builder.WriteByte(')')
+	}
+}
+
+// NewOutput is easy to create new Output
+//
+//	// examples
+//	// INSERT INTO `users` (`name`) OUTPUT INSERTED.`id`,INSERTED.`created_at` VALUES ('WinterYukky')
+//	db.Clauses(exclause.NewOutput(exclause.Inserted("id"), exclause.Inserted("created_at"))).Create(&user)
+func NewOutput(columns ...OutputColumn) Output {
+	return Output{Columns: columns}
+}
@@ -0,0 +1,175 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type outputUser struct {
+	ID   uint
+	Name string
+}
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) (*gorm.DB, interface{})
+		want      string
+		wantArgs  []driver.Value
+		columns   []string
+		values    []driver.Value
+		wantModel interface{}
+		wantErr   error
+	}{
+		{
+			name:    "When create has Output, then should be written between columns and VALUES and scanned into the model",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) (*gorm.DB, interface{}) {
+				user := outputUser{Name: "WinterYukky"}
+				return db.Clauses(NewOutput(Inserted("id"))).Create(&user), &user
+			},
+			want:      "INSERT INTO `output_users` (`name`) OUTPUT INSERTED.`id` VALUES (?)",
+			wantArgs:  []driver.Value{"WinterYukky"},
+			columns:   []string{"id"},
+			values:    []driver.Value{1},
+			wantModel: &outputUser{ID: 1, Name: "WinterYukky"},
+		},
+		{
+			name:    "When update has Output, then should be written after SET and scanned into the model",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) (*gorm.DB, interface{}) {
+				user := outputUser{ID: 1}
+				return db.Clauses(NewOutput(Inserted("name"), Deleted("name").As("old_name"))).Model(&user).Update("name", "Yukky"), &user
+			},
+			want:      "UPDATE `output_users` SET `name`=? OUTPUT INSERTED.`name`,DELETED.`name` AS `old_name` WHERE `id` = ?",
+			wantArgs:  []driver.Value{"Yukky", 1},
+			columns:   []string{"name", "old_name"},
+			values:    []driver.Value{"Yukky", "WinterYukky"},
+			wantModel: &outputUser{ID: 1, Name: "Yukky"},
+		},
+		{
+			name:    "When update has Output and UpdateFrom, then should be written before FROM",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) (*gorm.DB, interface{}) {
+				user := outputUser{ID: 1}
+				return db.Clauses(NewOutput(Inserted("*")), NewUpdateFrom("profiles")).Model(&user).Update("name", gorm.Expr("`profiles`.`name`")), &user
+			},
+			want:      "UPDATE `output_users` SET `name`=`profiles`.`name` OUTPUT INSERTED.* FROM `output_users` CROSS JOIN `profiles` WHERE `output_users`.`id` = ?",
+			wantArgs:  []driver.Value{1},
+			columns:   []string{"id", "name"},
+			values:    []driver.Value{1, "Yukky"},
+			wantModel: &outputUser{ID: 1, Name: "Yukky"},
+		},
+		{
+			name:    "When delete has Output, then should be written after FROM and scanned into the model",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) (*gorm.DB, interface{}) {
+				user := outputUser{ID: 1}
+				return db.Clauses(NewOutput(Deleted("name"))).Delete(&user), &user
+			},
+			want:      "DELETE FROM `output_users` OUTPUT DELETED.`name` WHERE `output_users`.`id` = ?",
+			wantArgs:  []driver.Value{1},
+			columns:   []string{"name"},
+			values:    []driver.Value{"WinterYukky"},
+			wantModel: &outputUser{ID: 1, Name: "WinterYukky"},
+		},
+		{
+			name:    "When delete has Output and DeleteUsing, then should be written between target table and FROM",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) (*gorm.DB, interface{}) {
+				user := outputUser{ID: 1}
+				return db.Clauses(NewOutput(Deleted("name")), NewDeleteUsing("profiles", clause.Expr{SQL: "`output_users`.`id` = `profiles`.`user_id`"})).Delete(&user), &user
+			},
+			want:      "DELETE `output_users` OUTPUT DELETED.`name` FROM `output_users` CROSS JOIN `profiles` WHERE `output_users`.`id` = `profiles`.`user_id` AND `output_users`.`id` = ?",
+			wantArgs:  []driver.Value{1},
+			columns:   []string{"name"},
+			values:    []driver.Value{"WinterYukky"},
+			wantModel: &outputUser{ID: 1, Name: "WinterYukky"},
+		},
+		{
+			name:    "When DeleteUsing is given before Output, then should be written between target table and FROM",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) (*gorm.DB, interface{}) {
+				user := outputUser{ID: 1}
+				return db.Clauses(NewDeleteUsing("profiles", clause.Expr{SQL: "`output_users`.`id` = `profiles`.`user_id`"})).
+					Clauses(NewOutput(Deleted("name"))).Where("`profiles`.`banned` = ?", true).Delete(&user), &user
+			},
+			want:      "DELETE `output_users` OUTPUT DELETED.`name` FROM `output_users` CROSS JOIN `profiles` WHERE `output_users`.`id` = `profiles`.`user_id` AND `profiles`.`banned` = ? AND `output_users`.`id` = ?",
+			wantArgs:  []driver.Value{true, 1},
+			columns:   []string{"name"},
+			values:    []driver.Value{"WinterYukky"},
+			wantModel: &outputUser{ID: 1, Name: "WinterYukky"},
+		},
+		{
+			name:    "When dialect is not sqlserver, then should be error",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) (*gorm.DB, interface{}) {
+				user := outputUser{Name: "WinterYukky"}
+				return db.Clauses(NewOutput(Inserted("id"))).Create(&user), &user
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows(tt.columns).AddRow(tt.values...))
+				mock.ExpectCommit()
+			}
+			db, got := tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error = %v, want %v", db.Error, tt.wantErr)
+				}
+				return
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if !reflect.DeepEqual(got, tt.wantModel) {
+				t.Errorf("model = %v, want %v", got, tt.wantModel)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestOutput_DeleteUsing_Rebuild(t *testing.T) {
+	db, _ := openDialectDB(t, dialectSQLServer)
+	db = db.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}).
+		Clauses(NewOutput(Deleted("name")), NewDeleteUsing("profiles", clause.Expr{SQL: "`output_users`.`id` = `profiles`.`user_id`"})).
+		Delete(&outputUser{ID: 1})
+	if db.Error != nil {
+		t.Fatal(db.Error)
+	}
+	if _, ok := db.Statement.Clauses["OUTPUT"]; !ok {
+		t.Errorf("OUTPUT clause is removed from the statement")
+	}
+
+	want := db.Statement.SQL.String()
+	db.Statement.SQL.Reset()
+	db.Statement.Vars = nil
+	db.Statement.Build(db.Statement.BuildClauses...)
+	if got := db.Statement.SQL.String(); got != want {
+		t.Errorf("rebuilt SQL is %q, want %q", got, want)
+	}
+}
+
+func TestNewOutput(t *testing.T) {
+	want := Output{Columns: []OutputColumn{{Source: OutputInserted, Name: "id"}, {Source: OutputDeleted, Name: "name"}}}
+	if got := NewOutput(Inserted("id"), Deleted("name")); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewOutput() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,435 @@
+package exclause
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidSQL is returned by ParseWith and ParseSetOperation when the SQL can't be parsed
+var ErrInvalidSQL = fmt.Errorf("%w: can't parse SQL", gorm.ErrInvalidData)
+
+// ParseWith parses the leading WITH section of raw SQL into With, and returns the rest of the SQL as the main query.
+// Each ? placeholder is mapped to the argument in order, and the arguments are passed to the CTE or the main query that has the placeholder.
+// The CTE bodies which have top-level set operations are parsed by ParseSetOperation.
+// The SQL is parsed as standard SQL, use ParseWithDialect for the quoting of the dialect.
+//
+//	// examples
+//	with, query, err := exclause.ParseWith("WITH `cte` AS (SELECT * FROM `users` WHERE `name` = ?) SELECT * FROM `cte` WHERE `age` > ?", "WinterYukky", 20)
+//	// with:  exclause.With{CTEs: []exclause.CTE{{Name: "cte", Subquery: clause.Expr{SQL: "SELECT * FROM `users` WHERE `name` = ?", Vars: []interface{}{"WinterYukky"}}}}}
+//	// query: clause.Expr{SQL: "SELECT * FROM `cte` WHERE `age` > ?", Vars: []interface{}{20}}
+//
+//	// WITH `cte` AS (SELECT * FROM `users` WHERE `name` = 'WinterYukky') SELECT * FROM `cte` WHERE `age` > 20
+//	db.Clauses(with).Table("cte").Where("`age` > ?", 20).Scan(&users)
+func ParseWith(sql string, args ...interface{}) (With, clause.Expr, error) {
+	return ParseWithDialect("", sql, args...)
+}
+
+// ParseWithDialect is ParseWith for the SQL of the dialect, that is the name of gorm dialector such as db.Dialector.Name().
+// Backslash escapes the next character in string literals on MySQL, e.g. 'it\'s',
+// and brackets quote identifiers on SQL Server, e.g. [user name]. Otherwise they are standard SQL such as 'C:\' and ARRAY[?, ?].
+//
+//	// examples
+//	// SQL Server: the brackets quote the identifiers
+//	with, query, err := exclause.ParseWithDialect(db.Dialector.Name(), "WITH [cte] AS (SELECT * FROM [users] WHERE [name] = ?) SELECT * FROM [cte]", "WinterYukky")
+func ParseWithDialect(dialect string, sql string, args ...interface{}) (With, clause.Expr, error) {
+	parser, err := newSQLParser(sql, dialect, args)
+	if err != nil {
+		return With{}, clause.Expr{}, err
+	}
+	if !parser.acceptWord("WITH") {
+		return With{}, clause.Expr{}, fmt.Errorf("%w: SQL doesn't start with WITH", ErrInvalidSQL)
+	}
+
+	with := With{Recursive: parser.acceptWord("RECURSIVE")}
+	for {
+		cte, err := parser.parseCTE()
+		if err != nil {
+			return With{}, clause.Expr{}, err
+		}
+		with.CTEs = append(with.CTEs, cte)
+		if !parser.acceptSymbol(",") {
+			break
+		}
+	}
+
+	if parser.position >= len(parser.tokens) {
+		return With{}, clause.Expr{}, fmt.Errorf("%w: WITH has no main query", ErrInvalidSQL)
+	}
+	return with, parser.expression(parser.position, len(parser.tokens)), nil
+}
+
+// ParseSetOperation parses the top-level UNION, INTERSECT and EXCEPT (or MINUS) branches of raw SQL into SetOperation.
+// INTERSECT binds tighter than UNION and EXCEPT, and the mixed operators are nested in that order, so the rendered SQL is the same as the raw SQL.
+// Set operations in parentheses are kept in the branch, and SQL without set operation is returned as SetOperation of one statement.
+// The SQL is parsed as standard SQL, use ParseSetOperationDialect for the quoting of the dialect.
+//
+//	// examples
+//	operation, err := exclause.ParseSetOperation("SELECT `id` FROM `admins` WHERE `age` > ? UNION ALL SELECT `id` FROM `owners`", 20)
+//	// operation: exclause.SetOperation{Operator: exclause.SetOperatorUnion, All: true, Statements: []clause.Expression{
+//	//	clause.Expr{SQL: "SELECT `id` FROM `admins` WHERE `age` > ?", Vars: []interface{}{20}},
+//	//	clause.Expr{SQL: "SELECT `id` FROM `owners`"},
+//	// }}
+//
+//	// SELECT * FROM `users` WHERE `id` IN (SELECT `id` FROM `admins` WHERE `age` > 20 UNION ALL SELECT `id` FROM `owners`)
+//	db.Table("users").Where("`id` IN (?)", operation).Scan(&users)
+func ParseSetOperation(sql string, args ...interface{}) (SetOperation, error) {
+	return ParseSetOperationDialect("", sql, args...)
+}
+
+// ParseSetOperationDialect is ParseSetOperation for the SQL of the dialect, the quoting of the dialect is the same as ParseWithDialect.
+//
+//	// examples
+//	// MySQL: the backslash escapes the quote in the string literal
+//	operation, err := exclause.ParseSetOperationDialect(db.Dialector.Name(), "SELECT 'it\\'s' UNION SELECT `name` FROM `users`")
+func ParseSetOperationDialect(dialect string, sql string, args ...interface{}) (SetOperation, error) {
+	parser, err := newSQLParser(sql, dialect, args)
+	if err != nil {
+		return SetOperation{}, err
+	}
+	expression, err := parser.parseSetOperation(0, len(parser.tokens), 0)
+	if err != nil {
+		return SetOperation{}, err
+	}
+	if operation, ok := expression.(SetOperation); ok {
+		return operation, nil
+	}
+	return SetOperation{Statements: []clause.Expression{expression}}, nil
+}
+
+type sqlTokenKind int
+
+const (
+	sqlWord sqlTokenKind = iota
+	sqlIdentifier
+	sqlString
+	sqlPlaceholder
+	sqlSymbol
+)
+
+// sqlToken is a token of raw SQL, comments and spaces are skipped
+type sqlToken struct {
+	kind sqlTokenKind
+	// text is the raw text, or the name of quoted identifier
+	text       string
+	start, end int
+	// depth is the depth of parentheses, a parenthesis has the depth outside of it
+	depth int
+	// arg is the index of the argument of the placeholder
+	arg int
+}
+
+// tokenizeSQL splits raw SQL of the dialect into tokens
+func tokenizeSQL(sql string, dialect string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	depth, placeholders := 0, 0
+	for index := 0; index < len(sql); {
+		c := sql[index]
+		token := sqlToken{kind: sqlSymbol, start: index, end: index + 1, depth: depth}
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			index++
+			continue
+		case strings.HasPrefix(sql[index:], "--"):
+			if end := strings.IndexByte(sql[index:], '\n'); end >= 0 {
+				index += end + 1
+			} else {
+				index = len(sql)
+			}
+			continue
+		case strings.HasPrefix(sql[index:], "/*"):
+			end := strings.Index(sql[index+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated comment at %d", ErrInvalidSQL, index)
+			}
+			index += end + 4
+			continue
+		case c == '\'' || c == '"' || c == '`' || (c == '[' && dialect == dialectSQLServer):
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			backslash := c == '\'' && dialect == dialectMySQL
+			end := scanQuoted(sql, index, closing, backslash)
+			if end < 0 && backslash {
+				return nil, fmt.Errorf("%w: unterminated string at %d, backslash escapes the next character in string literal", ErrInvalidSQL, index)
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated quote at %d", ErrInvalidSQL, index)
+			}
+			token.end = end
+			if c == '\'' {
+				token.kind = sqlString
+			} else {
+				token.kind = sqlIdentifier
+				quote := string(closing)
+				token.text = strings.ReplaceAll(sql[index+1:end-1], quote+quote, quote)
+			}
+		case c == '?':
+			token.kind = sqlPlaceholder
+			token.arg = placeholders
+			placeholders++
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("%w: unbalanced parenthesis at %d", ErrInvalidSQL, index)
+			}
+			token.depth = depth
+		case isSQLWordByte(c):
+			token.kind = sqlWord
+			for token.end < len(sql) && isSQLWordByte(sql[token.end]) {
+				token.end++
+			}
+		}
+		if token.kind != sqlIdentifier {
+			token.text = sql[token.start:token.end]
+		}
+		tokens = append(tokens, token)
+		index = token.end
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parenthesis", ErrInvalidSQL)
+	}
+	return tokens, nil
+}
+
+// scanQuoted returns the end of quoted text that starts at start, or -1 when it is not closed.
+// The doubled closing quote is escaped quote, and backslash escapes the next character with backslash as MySQL does.
+func scanQuoted(sql string, start int, closing byte, backslash bool) int {
+	for index := start + 1; index < len(sql); index++ {
+		if backslash && sql[index] == '\\' {
+			index++
+			continue
+		}
+		if sql[index] != closing {
+			continue
+		}
+		if index+1 < len(sql) && sql[index+1] == closing {
+			index++
+			continue
+		}
+		return index + 1
+	}
+	return -1
+}
+
+func isSQLWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// sqlParser parses tokens of raw SQL
+type sqlParser struct {
+	sql      string
+	tokens   []sqlToken
+	args     []interface{}
+	position int
+}
+
+func newSQLParser(sql string, dialect string, args []interface{}) (*sqlParser, error) {
+	tokens, err := tokenizeSQL(sql, dialect)
+	if err != nil {
+		return nil, err
+	}
+	placeholders := 0
+	for _, token := range tokens {
+		if token.kind == sqlPlaceholder {
+			placeholders++
+		}
+	}
+	if placeholders != len(args) {
+		return nil, fmt.Errorf("%w: %d placeholders but %d arguments", ErrInvalidSQL, placeholders, len(args))
+	}
+	return &sqlParser{sql: sql, tokens: tokens, args: args}, nil
+}
+
+// acceptWord consumes the next token if it is the keyword
+func (parser *sqlParser) acceptWord(keyword string) bool {
+	if parser.position < len(parser.tokens) {
+		if token := parser.tokens[parser.position]; token.kind == sqlWord && strings.EqualFold(token.text, keyword) {
+			parser.position++
+			return true
+		}
+	}
+	return false
+}
+
+// acceptSymbol consumes the next token if it is the symbol
+func (parser *sqlParser) acceptSymbol(symbol string) bool {
+	if parser.position < len(parser.tokens) {
+		if token := parser.tokens[parser.position]; token.kind == sqlSymbol && token.text == symbol {
+			parser.position++
+			return true
+		}
+	}
+	return false
+}
+
+// identifier consumes the next token if it is a word or quoted identifier
+func (parser *sqlParser) identifier() (string, bool) {
+	if parser.position < len(parser.tokens) {
+		if token := parser.tokens[parser.position]; token.kind == sqlWord || token.kind == sqlIdentifier {
+			parser.position++
+			return token.text, true
+		}
+	}
+	return "", false
+}
+
+// parseCTE parses name [(columns)] AS [[NOT] MATERIALIZED] (subquery)
+func (parser *sqlParser) parseCTE() (CTE, error) {
+	name, ok := parser.identifier()
+	if !ok {
+		return CTE{}, fmt.Errorf("%w: CTE name is expected", ErrInvalidSQL)
+	}
+	cte := CTE{Name: name}
+	if parser.acceptSymbol("(") {
+		for {
+			column, ok := parser.identifier()
+			if !ok {
+				return CTE{}, fmt.Errorf("%w: column name of CTE %s is expected", ErrInvalidSQL, name)
+			}
+			cte.Columns = append(cte.Columns, column)
+			if !parser.acceptSymbol(",") {
+				break
+			}
+		}
+		if !parser.acceptSymbol(")") {
+			return CTE{}, fmt.Errorf("%w: ) of CTE %s columns is expected", ErrInvalidSQL, name)
+		}
+	}
+	if !parser.acceptWord("AS") {
+		return CTE{}, fmt.Errorf("%w: AS of CTE %s is expected", ErrInvalidSQL, name)
+	}
+	if parser.acceptWord("NOT") {
+		if !parser.acceptWord("MATERIALIZED") {
+			return CTE{}, fmt.Errorf("%w: MATERIALIZED of CTE %s is expected", ErrInvalidSQL, name)
+		}
+		cte.Materialized = CTENotMaterialize
+	} else if parser.acceptWord("MATERIALIZED") {
+		cte.Materialized = CTEMaterialize
+	}
+
+	open := parser.position
+	if !parser.acceptSymbol("(") {
+		return CTE{}, fmt.Errorf("%w: subquery of CTE %s is expected", ErrInvalidSQL, name)
+	}
+	closing := parser.closingParenthesis(open)
+	subquery, err := parser.parseSetOperation(open+1, closing, parser.tokens[open].depth+1)
+	if err != nil {
+		return CTE{}, err
+	}
+	cte.Subquery = subquery
+	parser.position = closing + 1
+	return cte, nil
+}
+
+// closingParenthesis returns the index of the parenthesis that closes the parenthesis at open
+func (parser *sqlParser) closingParenthesis(open int) int {
+	depth := parser.tokens[open].depth
+	for index := open + 1; index < len(parser.tokens); index++ {
+		if token := parser.tokens[index]; token.kind == sqlSymbol && token.text == ")" && token.depth == depth {
+			return index
+		}
+	}
+	// tokenizeSQL has checked parentheses are balanced
+	return len(parser.tokens)
+}
+
+var setOperatorKeywords = map[string]SetOperator{
+	"UNION":     SetOperatorUnion,
+	"INTERSECT": SetOperatorIntersect,
+	"EXCEPT":    SetOperatorExcept,
+	"MINUS":     SetOperatorExcept,
+}
+
+type parsedSetOperator struct {
+	operator SetOperator
+	all      bool
+}
+
+// parseSetOperation parses tokens from from to to, splitting them by set operators at the depth
+func (parser *sqlParser) parseSetOperation(from, to, depth int) (clause.Expression, error) {
+	var operands []clause.Expression
+	var operators []parsedSetOperator
+	branch := from
+	for index := from; index < to; index++ {
+		token := parser.tokens[index]
+		if token.kind != sqlWord || token.depth != depth {
+			continue
+		}
+		operator, ok := setOperatorKeywords[strings.ToUpper(token.text)]
+		if !ok {
+			continue
+		}
+		if branch == index {
+			return nil, fmt.Errorf("%w: query is expected before %s", ErrInvalidSQL, token.text)
+		}
+		operands = append(operands, parser.expression(branch, index))
+		parsed := parsedSetOperator{operator: operator}
+		if next := index + 1; next < to && parser.tokens[next].kind == sqlWord {
+			if strings.EqualFold(parser.tokens[next].text, "ALL") {
+				parsed.all = true
+				index++
+			} else if strings.EqualFold(parser.tokens[next].text, "DISTINCT") {
+				index++
+			}
+		}
+		operators = append(operators, parsed)
+		branch = index + 1
+	}
+	if branch >= to {
+		return nil, fmt.Errorf("%w: query is expected", ErrInvalidSQL)
+	}
+	operands = append(operands, parser.expression(branch, to))
+
+	// INTERSECT binds tighter than UNION and EXCEPT
+	operands, operators = reduceSetOperations(operands, operators, func(operator SetOperator) bool {
+		return operator == SetOperatorIntersect
+	})
+	operands, _ = reduceSetOperations(operands, operators, func(SetOperator) bool {
+		return true
+	})
+	return operands[0], nil
+}
+
+// reduceSetOperations combines the operands of matched operators into SetOperation from left to right
+func reduceSetOperations(operands []clause.Expression, operators []parsedSetOperator, match func(SetOperator) bool) ([]clause.Expression, []parsedSetOperator) {
+	reducedOperands := []clause.Expression{operands[0]}
+	var reducedOperators []parsedSetOperator
+	for index, parsed := range operators {
+		right := operands[index+1]
+		if !match(parsed.operator) {
+			reducedOperands = append(reducedOperands, right)
+			reducedOperators = append(reducedOperators, parsed)
+			continue
+		}
+		last := len(reducedOperands) - 1
+		if left, ok := reducedOperands[last].(SetOperation); ok && left.Operator == parsed.operator && left.All == parsed.all {
+			left.Statements = append(left.Statements, right)
+			reducedOperands[last] = left
+		} else {
+			reducedOperands[last] = SetOperation{
+				Operator:   parsed.operator,
+				All:        parsed.all,
+				Statements: []clause.Expression{reducedOperands[last], right},
+			}
+		}
+	}
+	return reducedOperands, reducedOperators
+}
+
+// expression returns the SQL of tokens from from to to with the arguments of their placeholders
+func (parser *sqlParser) expression(from, to int) clause.Expr {
+	expr := clause.Expr{SQL: parser.sql[parser.tokens[from].start:parser.tokens[to-1].end]}
+	for _, token := range parser.tokens[from:to] {
+		if token.kind == sqlPlaceholder {
+			expr.Vars = append(expr.Vars, parser.args[token.arg])
+		}
+	}
+	return expr
+}
@@ -0,0 +1,294 @@
+package exclause
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm/clause"
+)
+
+func TestParseWith(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		sql       string
+		args      []interface{}
+		wantWith  With
+		wantQuery clause.Expr
+		wantErr   error
+	}{
+		{
+			name: "When SQL has a CTE, then should be parsed with its arguments",
+			sql:  "WITH `cte` AS (SELECT * FROM `users` WHERE `name` = ?) SELECT * FROM `cte` WHERE `age` > ?",
+			args: []interface{}{"WinterYukky", 20},
+			wantWith: With{CTEs: []CTE{
+				{Name: "cte", Subquery: clause.Expr{SQL: "SELECT * FROM `users` WHERE `name` = ?", Vars: []interface{}{"WinterYukky"}}},
+			}},
+			wantQuery: clause.Expr{SQL: "SELECT * FROM `cte` WHERE `age` > ?", Vars: []interface{}{20}},
+		},
+		{
+			name: "When SQL has recursive CTEs with columns and materialization, then should be parsed",
+			sql: `with recursive "tree" ("id", parent_id) as not materialized (
+				SELECT id, parent_id FROM nodes WHERE id = ?
+				UNION ALL
+				SELECT n.id, n.parent_id FROM nodes n JOIN tree t ON n.parent_id = t.id
+			), leaves AS MATERIALIZED (SELECT * FROM tree WHERE (SELECT COUNT(*) FROM nodes c WHERE c.parent_id = tree.id) = 0)
+			SELECT * FROM leaves`,
+			args: []interface{}{1},
+			wantWith: With{Recursive: true, CTEs: []CTE{
+				{
+					Name:    "tree",
+					Columns: []string{"id", "parent_id"},
+					Subquery: SetOperation{Operator: SetOperatorUnion, All: true, Statements: []clause.Expression{
+						clause.Expr{SQL: "SELECT id, parent_id FROM nodes WHERE id = ?", Vars: []interface{}{1}},
+						clause.Expr{SQL: "SELECT n.id, n.parent_id FROM nodes n JOIN tree t ON n.parent_id = t.id"},
+					}},
+					Materialized: CTENotMaterialize,
+				},
+				{
+					Name:         "leaves",
+					Subquery:     clause.Expr{SQL: "SELECT * FROM tree WHERE (SELECT COUNT(*) FROM nodes c WHERE c.parent_id = tree.id) = 0"},
+					Materialized: CTEMaterialize,
+				},
+			}},
+			wantQuery: clause.Expr{SQL: "SELECT * FROM leaves"},
+		},
+		{
+			name: "When SQL has placeholders and parentheses in strings and comments, then should be ignored",
+			sql:  "WITH cte AS (SELECT '?)' AS `a?`, ? AS b /* ? ) */ FROM users -- ?)\n) SELECT * FROM cte",
+			args: []interface{}{1},
+			wantWith: With{CTEs: []CTE{
+				{Name: "cte", Subquery: clause.Expr{SQL: "SELECT '?)' AS `a?`, ? AS b /* ? ) */ FROM users", Vars: []interface{}{1}}},
+			}},
+			wantQuery: clause.Expr{SQL: "SELECT * FROM cte"},
+		},
+		{
+			name:    "When SQL doesn't start with WITH, then should be error",
+			sql:     "SELECT * FROM users",
+			wantErr: ErrInvalidSQL,
+		},
+		{
+			name:    "When SQL has no main query, then should be error",
+			sql:     "WITH cte AS (SELECT * FROM users)",
+			wantErr: ErrInvalidSQL,
+		},
+		{
+			name:    "When CTE has no AS, then should be error",
+			sql:     "WITH cte (SELECT * FROM users) SELECT * FROM cte",
+			wantErr: ErrInvalidSQL,
+		},
+		{
+			name:    "When parentheses are unbalanced, then should be error",
+			sql:     "WITH cte AS (SELECT * FROM users SELECT * FROM cte",
+			wantErr: ErrInvalidSQL,
+		},
+		{
+			name:    "When string has backslash escaped quote on mysql, then should be kept in the string",
+			dialect: dialectMySQL,
+			sql:     "WITH cte AS (SELECT 'it\\'s ?)' AS a) SELECT * FROM cte WHERE a <> ?",
+			args:    []interface{}{"x"},
+			wantWith: With{CTEs: []CTE{
+				{Name: "cte", Subquery: clause.Expr{SQL: "SELECT 'it\\'s ?)' AS a"}},
+			}},
+			wantQuery: clause.Expr{SQL: "SELECT * FROM cte WHERE a <> ?", Vars: []interface{}{"x"}},
+		},
+		{
+			name:    "When string ends with backslash on mysql, then should be error",
+			dialect: dialectMySQL,
+			sql:     "WITH cte AS (SELECT 'C:\\' AS a) SELECT * FROM cte",
+			wantErr: ErrInvalidSQL,
+		},
+		{
+			name:    "When string ends with backslash on postgres, then should be standard string literal",
+			dialect: dialectPostgres,
+			sql:     "WITH cte AS (SELECT 'C:\\' AS a, ? AS b) SELECT * FROM cte",
+			args:    []interface{}{1},
+			wantWith: With{CTEs: []CTE{
+				{Name: "cte", Subquery: clause.Expr{SQL: "SELECT 'C:\\' AS a, ? AS b", Vars: []interface{}{1}}},
+			}},
+			wantQuery: clause.Expr{SQL: "SELECT * FROM cte"},
+		},
+		{
+			name:    "When brackets are used on postgres, then should be placeholders in the array",
+			dialect: dialectPostgres,
+			sql:     "WITH cte AS (SELECT ARRAY[?, ?] AS a) SELECT a[?] FROM cte",
+			args:    []interface{}{1, 2, 1},
+			wantWith: With{CTEs: []CTE{
+				{Name: "cte", Subquery: clause.Expr{SQL: "SELECT ARRAY[?, ?] AS a", Vars: []interface{}{1, 2}}},
+			}},
+			wantQuery: clause.Expr{SQL: "SELECT a[?] FROM cte", Vars: []interface{}{1}},
+		},
+		{
+			name:    "When brackets are used on sqlserver, then should be quoted identifier",
+			dialect: dialectSQLServer,
+			sql:     "WITH [my cte] AS (SELECT [a?] FROM users WHERE id = ?) SELECT * FROM [my cte]",
+			args:    []interface{}{1},
+			wantWith: With{CTEs: []CTE{
+				{Name: "my cte", Subquery: clause.Expr{SQL: "SELECT [a?] FROM users WHERE id = ?", Vars: []interface{}{1}}},
+			}},
+			wantQuery: clause.Expr{SQL: "SELECT * FROM [my cte]"},
+		},
+		{
+			name:    "When string is not terminated, then should be error",
+			sql:     "WITH cte AS (SELECT 'a FROM users) SELECT * FROM cte",
+			wantErr: ErrInvalidSQL,
+		},
+		{
+			name:    "When arguments don't match placeholders, then should be error",
+			sql:     "WITH cte AS (SELECT * FROM users WHERE id = ?) SELECT * FROM cte",
+			args:    []interface{}{1, 2},
+			wantErr: ErrInvalidSQL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotWith, gotQuery, err := ParseWithDialect(tt.dialect, tt.sql, tt.args...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error is %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotWith, tt.wantWith) {
+				t.Errorf("ParseWith() with = %#v, want %#v", gotWith, tt.wantWith)
+			}
+			if !reflect.DeepEqual(gotQuery, tt.wantQuery) {
+				t.Errorf("ParseWith() query = %#v, want %#v", gotQuery, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestParseSetOperation(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect string
+		sql     string
+		args    []interface{}
+		want    SetOperation
+		wantErr error
+	}{
+		{
+			name: "When SQL has unions, then should be parsed with its arguments",
+			sql:  "SELECT id FROM admins WHERE age > ? UNION SELECT id FROM owners UNION SELECT id FROM guests WHERE age < ?",
+			args: []interface{}{20, 30},
+			want: SetOperation{Operator: SetOperatorUnion, Statements: []clause.Expression{
+				clause.Expr{SQL: "SELECT id FROM admins WHERE age > ?", Vars: []interface{}{20}},
+				clause.Expr{SQL: "SELECT id FROM owners"},
+				clause.Expr{SQL: "SELECT id FROM guests WHERE age < ?", Vars: []interface{}{30}},
+			}},
+		},
+		{
+			name: "When SQL has UNION ALL and UNION, then should be nested from left",
+			sql:  "SELECT 1 UNION ALL SELECT 2 union distinct SELECT 3",
+			want: SetOperation{Operator: SetOperatorUnion, Statements: []clause.Expression{
+				SetOperation{Operator: SetOperatorUnion, All: true, Statements: []clause.Expression{
+					clause.Expr{SQL: "SELECT 1"},
+					clause.Expr{SQL: "SELECT 2"},
+				}},
+				clause.Expr{SQL: "SELECT 3"},
+			}},
+		},
+		{
+			name: "When SQL has UNION and INTERSECT, then INTERSECT should bind tighter",
+			sql:  "SELECT 1 UNION SELECT 2 INTERSECT SELECT 3 MINUS SELECT 4",
+			want: SetOperation{Operator: SetOperatorExcept, Statements: []clause.Expression{
+				SetOperation{Operator: SetOperatorUnion, Statements: []clause.Expression{
+					clause.Expr{SQL: "SELECT 1"},
+					SetOperation{Operator: SetOperatorIntersect, Statements: []clause.Expression{
+						clause.Expr{SQL: "SELECT 2"},
+						clause.Expr{SQL: "SELECT 3"},
+					}},
+				}},
+				clause.Expr{SQL: "SELECT 4"},
+			}},
+		},
+		{
+			name: "When set operation is in parentheses, then should be kept in the branch",
+			sql:  "SELECT id FROM admins EXCEPT (SELECT id FROM owners UNION SELECT id FROM guests)",
+			want: SetOperation{Operator: SetOperatorExcept, Statements: []clause.Expression{
+				clause.Expr{SQL: "SELECT id FROM admins"},
+				clause.Expr{SQL: "(SELECT id FROM owners UNION SELECT id FROM guests)"},
+			}},
+		},
+		{
+			name: "When SQL has no set operation, then should be one statement",
+			sql:  "SELECT * FROM users WHERE id IN (SELECT id FROM admins UNION SELECT id FROM owners)",
+			want: SetOperation{Statements: []clause.Expression{
+				clause.Expr{SQL: "SELECT * FROM users WHERE id IN (SELECT id FROM admins UNION SELECT id FROM owners)"},
+			}},
+		},
+		{
+			name:    "When string has backslash escaped quote on mysql, then should not be split in the string",
+			dialect: dialectMySQL,
+			sql:     "SELECT 'it\\'s ?' UNION SELECT ?",
+			args:    []interface{}{1},
+			want: SetOperation{Operator: SetOperatorUnion, Statements: []clause.Expression{
+				clause.Expr{SQL: "SELECT 'it\\'s ?'"},
+				clause.Expr{SQL: "SELECT ?", Vars: []interface{}{1}},
+			}},
+		},
+		{
+			name: "When string ends with backslash, then should be standard string literal",
+			sql:  "SELECT 'C:\\' UNION SELECT ?",
+			args: []interface{}{1},
+			want: SetOperation{Operator: SetOperatorUnion, Statements: []clause.Expression{
+				clause.Expr{SQL: "SELECT 'C:\\'"},
+				clause.Expr{SQL: "SELECT ?", Vars: []interface{}{1}},
+			}},
+		},
+		{
+			name: "When brackets are used, then should be placeholders in the array",
+			sql:  "SELECT ARRAY[?] UNION SELECT ARRAY[?]",
+			args: []interface{}{1, 2},
+			want: SetOperation{Operator: SetOperatorUnion, Statements: []clause.Expression{
+				clause.Expr{SQL: "SELECT ARRAY[?]", Vars: []interface{}{1}},
+				clause.Expr{SQL: "SELECT ARRAY[?]", Vars: []interface{}{2}},
+			}},
+		},
+		{
+			name:    "When branch is empty, then should be error",
+			sql:     "SELECT 1 UNION",
+			wantErr: ErrInvalidSQL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSetOperationDialect(tt.dialect, tt.sql, tt.args...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error is %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSetOperation() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWith_Build(t *testing.T) {
+	db, mock := openDialectDB(t, dialectMySQL)
+	with, _, err := ParseWith("WITH `cte` AS (SELECT * FROM `admins` WHERE `age` > ? UNION SELECT * FROM `owners`) SELECT * FROM `cte`", 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("WITH `cte` AS (SELECT * FROM `admins` WHERE `age` > ? UNION SELECT * FROM `owners`) SELECT * FROM `cte` WHERE `name` = ?")).
+		WithArgs(20, "WinterYukky").WillReturnRows(sqlmock.NewRows([]string{}))
+	if err := db.Clauses(with).Table("cte").Where("`name` = ?", "WinterYukky").Scan(nil).Error; err != nil {
+		t.Error(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
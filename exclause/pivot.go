@@ -0,0 +1,226 @@
+package exclause
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	pivotSourceAlias  = "exclause_source"
+	pivotInputAlias   = "exclause_input"
+	pivotResultAlias  = "exclause_pivot"
+	unpivotValueAlias = "exclause_unpivot"
+)
+
+// Pivot is cross-tab query expression that turns values of PivotColumn into columns.
+// It is rendered as PIVOT on SQL Server and Oracle, and conditional aggregation on other databases.
+// It can be used as CTE subquery or derived table.
+//
+//	// examples
+//	pivot := exclause.Pivot{
+//		Source:       "sales",
+//		GroupColumns: []string{"year"},
+//		Aggregate:    "SUM",
+//		ValueColumn:  "amount",
+//		PivotColumn:  "month",
+//		Values:       []exclause.PivotValue{{Value: 1, Alias: "m1"}, {Value: 2, Alias: "m2"}},
+//	}
+//
+//	// MySQL, PostgreSQL, SQLite:
+//	// WITH `cte` AS (SELECT `year`,SUM(CASE WHEN `month` = 1 THEN `amount` END) AS `m1`,SUM(CASE WHEN `month` = 2 THEN `amount` END) AS `m2`
+//	// FROM `sales` AS `exclause_source` GROUP BY `year`) SELECT * FROM `cte`
+//	//
+//	// SQL Server:
+//	// WITH `cte` AS (SELECT `year`,`1` AS `m1`,`2` AS `m2` FROM (SELECT `year`,`month`,`amount` FROM `sales` AS `exclause_source`) AS `exclause_input`
+//	// PIVOT (SUM(`amount`) FOR `month` IN (`1`,`2`)) AS `exclause_pivot`) SELECT * FROM `cte`
+//	//
+//	// Oracle:
+//	// WITH `cte` AS (SELECT * FROM (SELECT `year`,`month`,`amount` FROM `sales` `exclause_source`)
+//	// PIVOT (SUM(`amount`) FOR `month` IN (1 AS `m1`,2 AS `m2`))) SELECT * FROM `cte`
+//	db.Clauses(exclause.NewWith("cte", pivot)).Table("cte").Scan(&sales)
+//
+//	// SELECT * FROM (SELECT ...) AS `p`
+//	db.Table("(?) AS `p`", pivot).Scan(&sales)
+type Pivot struct {
+	// Source is table name, clause.Table, *gorm.DB or clause.Expression of subquery
+	Source       interface{}
+	GroupColumns []string
+	// Aggregate is aggregate function such as SUM, COUNT, MAX
+	Aggregate   string
+	ValueColumn string
+	PivotColumn string
+	Values      []PivotValue
+}
+
+// PivotValue is the value of pivot column that becomes a column named Alias
+type PivotValue struct {
+	Value interface{}
+	// Alias is the column name, default is the value
+	Alias string
+}
+
+func (value PivotValue) alias() string {
+	if value.Alias != "" {
+		return value.Alias
+	}
+	return fmt.Sprint(value.Value)
+}
+
+// Build build pivot
+func (pivot Pivot) Build(builder clause.Builder) {
+	switch dialect := dialectOf(builder); dialect {
+	case dialectSQLServer:
+		builder.WriteString("SELECT ")
+		for _, column := range pivot.GroupColumns {
+			builder.WriteQuoted(column)
+			builder.WriteByte(',')
+		}
+		for index, value := range pivot.Values {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(fmt.Sprint(value.Value))
+			builder.WriteString(" AS ")
+			builder.WriteQuoted(value.alias())
+		}
+		builder.WriteString(" FROM ")
+		pivot.buildInput(builder, dialect)
+		builder.WriteString(" PIVOT (")
+		pivot.buildAggregate(builder, clause.Column{Name: pivot.ValueColumn})
+		builder.WriteString(" FOR ")
+		builder.WriteQuoted(pivot.PivotColumn)
+		builder.WriteString(" IN (")
+		for index, value := range pivot.Values {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(fmt.Sprint(value.Value))
+		}
+		builder.WriteString("))")
+		writeAlias(builder, dialect, pivotResultAlias)
+	case dialectOracle:
+		builder.WriteString("SELECT * FROM ")
+		pivot.buildInput(builder, dialect)
+		builder.WriteString(" PIVOT (")
+		pivot.buildAggregate(builder, clause.Column{Name: pivot.ValueColumn})
+		builder.WriteString(" FOR ")
+		builder.WriteQuoted(pivot.PivotColumn)
+		builder.WriteString(" IN (")
+		for index, value := range pivot.Values {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			writeLiteral(builder, value.Value)
+			builder.WriteString(" AS ")
+			builder.WriteQuoted(value.alias())
+		}
+		builder.WriteString("))")
+	default:
+		builder.WriteString("SELECT ")
+		for _, column := range pivot.GroupColumns {
+			builder.WriteQuoted(column)
+			builder.WriteByte(',')
+		}
+		for index, value := range pivot.Values {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			pivot.buildAggregate(builder, clause.Expr{
+				SQL:  "CASE WHEN ? = ? THEN ? END",
+				Vars: []interface{}{clause.Column{Name: pivot.PivotColumn}, value.Value, clause.Column{Name: pivot.ValueColumn}},
+			})
+			builder.WriteString(" AS ")
+			builder.WriteQuoted(value.alias())
+		}
+		builder.WriteString(" FROM ")
+		buildPivotSource(builder, dialect, pivot.Source)
+		if len(pivot.GroupColumns) > 0 {
+			builder.WriteString(" GROUP BY ")
+			for index, column := range pivot.GroupColumns {
+				if index > 0 {
+					builder.WriteByte(',')
+				}
+				builder.WriteQuoted(column)
+			}
+		}
+	}
+}
+
+// buildInput writes the source that has only group, pivot and value columns, because PIVOT groups by all other columns
+func (pivot Pivot) buildInput(builder clause.Builder, dialect string) {
+	builder.WriteString("(SELECT ")
+	for _, column := range pivot.GroupColumns {
+		builder.WriteQuoted(column)
+		builder.WriteByte(',')
+	}
+	builder.WriteQuoted(pivot.PivotColumn)
+	builder.WriteByte(',')
+	builder.WriteQuoted(pivot.ValueColumn)
+	builder.WriteString(" FROM ")
+	buildPivotSource(builder, dialect, pivot.Source)
+	builder.WriteByte(')')
+	if dialect != dialectOracle {
+		writeAlias(builder, dialect, pivotInputAlias)
+	}
+}
+
+func (pivot Pivot) buildAggregate(builder clause.Builder, value interface{}) {
+	builder.WriteString(pivot.Aggregate)
+	builder.WriteByte('(')
+	builder.AddVar(builder, value)
+	builder.WriteByte(')')
+}
+
+// buildPivotSource writes the source table or subquery aliased as exclause_source
+func buildPivotSource(builder clause.Builder, dialect string, source interface{}) {
+	switch v := source.(type) {
+	case string:
+		builder.WriteQuoted(clause.Table{Name: v})
+	case clause.Table:
+		builder.WriteQuoted(v)
+	case *gorm.DB:
+		builder.WriteByte('(')
+		builder.AddVar(builder, v)
+		builder.WriteByte(')')
+	case clause.Expression:
+		builder.WriteByte('(')
+		v.Build(builder)
+		builder.WriteByte(')')
+	default:
+		builder.AddError(gorm.ErrInvalidValue)
+		return
+	}
+	writeAlias(builder, dialect, pivotSourceAlias)
+}
+
+// writeAlias writes the alias of table, Oracle doesn't accept AS for table alias
+func writeAlias(builder clause.Builder, dialect string, alias string) {
+	if dialect == dialectOracle {
+		builder.WriteByte(' ')
+	} else {
+		builder.WriteString(" AS ")
+	}
+	builder.WriteQuoted(alias)
+}
+
+// writeLiteral writes the value as SQL literal, for the clauses that don't accept bind variables such as PIVOT IN on Oracle
+func writeLiteral(builder clause.Builder, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		builder.WriteString("'" + strings.ReplaceAll(v, "'", "''") + "'")
+	case int:
+		builder.WriteString(strconv.Itoa(v))
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		builder.WriteString(fmt.Sprint(v))
+	case float32:
+		builder.WriteString(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		builder.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		builder.AddError(fmt.Errorf("%w: %T can't be written as literal", gorm.ErrInvalidValue, value))
+	}
+}
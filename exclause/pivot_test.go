@@ -0,0 +1,122 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestPivot(t *testing.T) {
+	pivot := Pivot{
+		Source:       "sales",
+		GroupColumns: []string{"year"},
+		Aggregate:    "SUM",
+		ValueColumn:  "amount",
+		PivotColumn:  "month",
+		Values:       []PivotValue{{Value: 1, Alias: "m1"}, {Value: 2, Alias: "m2"}},
+	}
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When dialect is mysql, then should be conditional aggregation",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", pivot)).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT `year`,SUM(CASE WHEN `month` = ? THEN `amount` END) AS `m1`,SUM(CASE WHEN `month` = ? THEN `amount` END) AS `m2` FROM `sales` AS `exclause_source` GROUP BY `year`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{1, 2},
+		},
+		{
+			name:    "When source is subquery and used as derived table, then should be conditional aggregation of the subquery",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("(?) AS `p`", Pivot{
+					Source:      db.Table("sales").Where("`year` = ?", 2024),
+					Aggregate:   "COUNT",
+					ValueColumn: "id",
+					PivotColumn: "status",
+					Values:      []PivotValue{{Value: "paid"}, {Value: "refunded"}},
+				}).Scan(nil)
+			},
+			want:     "SELECT * FROM (SELECT COUNT(CASE WHEN `status` = ? THEN `id` END) AS `paid`,COUNT(CASE WHEN `status` = ? THEN `id` END) AS `refunded` FROM (SELECT * FROM `sales` WHERE `year` = ?) AS `exclause_source`) AS `p`",
+			wantArgs: []driver.Value{"paid", "refunded", 2024},
+		},
+		{
+			name:    "When dialect is sqlserver, then should be PIVOT",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", pivot)).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT `year`,`1` AS `m1`,`2` AS `m2` FROM (SELECT `year`,`month`,`amount` FROM `sales` AS `exclause_source`) AS `exclause_input` PIVOT (SUM(`amount`) FOR `month` IN (`1`,`2`)) AS `exclause_pivot`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is oracle, then should be PIVOT with literals",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", Pivot{
+					Source:       clause.Table{Name: "sales"},
+					GroupColumns: []string{"year"},
+					Aggregate:    "SUM",
+					ValueColumn:  "amount",
+					PivotColumn:  "month",
+					Values:       []PivotValue{{Value: "Jan"}, {Value: "Feb's", Alias: "feb"}},
+				})).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM (SELECT `year`,`month`,`amount` FROM `sales` `exclause_source`) PIVOT (SUM(`amount`) FOR `month` IN ('Jan' AS `Jan`,'Feb''s' AS `feb`))) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is oracle and value can't be literal, then should be error",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", Pivot{
+					Source:      "sales",
+					Aggregate:   "SUM",
+					ValueColumn: "amount",
+					PivotColumn: "month",
+					Values:      []PivotValue{{Value: []int{1}}},
+				})).Table("cte").Scan(nil)
+			},
+			wantErr: gorm.ErrInvalidValue,
+		},
+		{
+			name:    "When source is invalid, then should be error",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", Pivot{Source: 1, Aggregate: "SUM", ValueColumn: "amount", PivotColumn: "month"})).Table("cte").Scan(nil)
+			},
+			wantErr: gorm.ErrInvalidValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeekDirection is the order of keyset pagination
+type SeekDirection int
+
+const (
+	// SeekAsc seeks rows after the last values in ascending order
+	SeekAsc SeekDirection = iota
+	// SeekDesc seeks rows before the last values in descending order
+	SeekDesc
+)
+
+const seekAlias = "exclause_seek"
+
+// Seek returns the scope of keyset pagination, that seeks rows after lastValues of columns and orders by columns.
+// The condition is row value comparison (a, b) > (?, ?) on MySQL, PostgreSQL and SQLite, and expanded a > ? OR (a = ? AND b > ?) on others.
+// Empty lastValues means the first page.
+// Queries that have set operations or GROUP BY are wrapped in a derived table, and WITH clause is kept in the outer query.
+//
+//	// examples
+//	// SELECT * FROM `users` WHERE (`created_at`,`id`) > ('2024-01-01 00:00:00',10) ORDER BY `created_at`,`id` LIMIT 20
+//	db.Table("users").Scopes(exclause.Seek([]string{"created_at", "id"}, []interface{}{createdAt, 10}, exclause.SeekAsc)).Limit(20).Find(&users)
+//
+//	// SELECT * FROM (SELECT * FROM `admins` UNION SELECT * FROM `owners`) AS `exclause_seek` WHERE `id` < 10 ORDER BY `id` DESC LIMIT 20
+//	db.Table("admins").Clauses(exclause.NewUnion(db.Table("owners"))).Scopes(exclause.Seek([]string{"id"}, []interface{}{10}, exclause.SeekDesc)).Limit(20).Find(&users)
+func Seek(columns []string, lastValues []interface{}, direction SeekDirection) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(lastValues) > 0 && len(lastValues) != len(columns) {
+			db.AddError(fmt.Errorf("%w: Seek has %d columns but %d values", gorm.ErrInvalidData, len(columns), len(lastValues)))
+			return db
+		}
+		if hasAnyClause(db.Statement, "UNION", "INTERSECT", "EXCEPT", "GROUP BY") {
+			wrapSeek(db)
+		}
+
+		desc := direction == SeekDesc
+		if len(lastValues) > 0 {
+			db = db.Where(seekCondition{Columns: columns, Values: lastValues, Desc: desc})
+		}
+		orderBy := clause.OrderBy{Columns: make([]clause.OrderByColumn, len(columns))}
+		for index, column := range columns {
+			orderBy.Columns[index] = clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc}
+		}
+		delete(db.Statement.Clauses, "ORDER BY")
+		return db.Order(orderBy)
+	}
+}
+
+// wrapSeek moves the query into a derived table, so that the seek condition and order are applied to the result of it
+func wrapSeek(db *gorm.DB) {
+	stmt := db.Statement
+	inner := db.Session(&gorm.Session{}).Clauses()
+	for _, name := range []string{"WITH", "ORDER BY", "LIMIT", "FOR"} {
+		delete(inner.Statement.Clauses, name)
+	}
+	for _, name := range []string{"SELECT", "FROM", "WHERE", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY"} {
+		delete(stmt.Clauses, name)
+	}
+	stmt.Selects, stmt.Omits, stmt.Joins, stmt.Distinct = nil, nil, nil, false
+
+	sql := "(?) AS ?"
+	if statementDialect(stmt) == dialectOracle {
+		sql = "(?) ?"
+	}
+	stmt.Table = seekAlias
+	stmt.TableExpr = &clause.Expr{SQL: sql, Vars: []interface{}{inner, clause.Table{Name: seekAlias}}}
+}
+
+func hasAnyClause(stmt *gorm.Statement, names ...string) bool {
+	for _, name := range names {
+		if _, ok := stmt.Clauses[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// seekCondition is the condition of rows after the values in the order of columns
+type seekCondition struct {
+	Columns []string
+	Values  []interface{}
+	Desc    bool
+}
+
+// Build build seek condition
+func (seek seekCondition) Build(builder clause.Builder) {
+	operator := " > "
+	if seek.Desc {
+		operator = " < "
+	}
+	if len(seek.Columns) == 1 {
+		builder.WriteQuoted(seek.Columns[0])
+		builder.WriteString(operator)
+		builder.AddVar(builder, seek.Values[0])
+		return
+	}
+
+	switch dialectOf(builder) {
+	case dialectMySQL, dialectPostgres, dialectSQLite:
+		builder.WriteByte('(')
+		for index, column := range seek.Columns {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(column)
+		}
+		builder.WriteByte(')')
+		builder.WriteString(operator)
+		builder.WriteByte('(')
+		builder.AddVar(builder, seek.Values...)
+		builder.WriteByte(')')
+	default:
+		conditions := make([]clause.Expression, len(seek.Columns))
+		for index := range seek.Columns {
+			exprs := make([]clause.Expression, 0, index+1)
+			for previous := 0; previous < index; previous++ {
+				exprs = append(exprs, clause.Eq{Column: clause.Column{Name: seek.Columns[previous]}, Value: seek.Values[previous]})
+			}
+			column := clause.Column{Name: seek.Columns[index]}
+			if seek.Desc {
+				exprs = append(exprs, clause.Lt{Column: column, Value: seek.Values[index]})
+			} else {
+				exprs = append(exprs, clause.Gt{Column: column, Value: seek.Values[index]})
+			}
+			conditions[index] = clause.And(exprs...)
+		}
+		clause.Or(conditions...).Build(builder)
+	}
+}
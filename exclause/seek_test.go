@@ -0,0 +1,117 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestSeek(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When dialect supports row values, then should be row value comparison",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where("`active` = ?", true).Scopes(Seek([]string{"created_at", "id"}, []interface{}{"2024-01-01", 10}, SeekAsc)).Limit(20).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `active` = ? AND (`created_at`,`id`) > (?,?) ORDER BY `created_at`,`id` LIMIT ?",
+			wantArgs: []driver.Value{true, "2024-01-01", 10, 20},
+		},
+		{
+			name:    "When last values are empty, then should be ordered only",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("`name`").Scopes(Seek([]string{"created_at", "id"}, nil, SeekDesc)).Limit(20).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY `created_at` DESC,`id` DESC LIMIT ?",
+			wantArgs: []driver.Value{20},
+		},
+		{
+			name:    "When dialect doesn't support row values, then should be expanded",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where("`active` = ?", true).Scopes(Seek([]string{"a", "b", "c"}, []interface{}{1, 2, 3}, SeekDesc)).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `active` = ? AND (`a` < ? OR (`a` = ? AND `b` < ?) OR (`a` = ? AND `b` = ? AND `c` < ?)) ORDER BY `a` DESC,`b` DESC,`c` DESC",
+			wantArgs: []driver.Value{true, 1, 1, 2, 1, 2, 3},
+		},
+		{
+			name:    "When one column is given, then should be simple comparison",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Scopes(Seek([]string{"id"}, []interface{}{10}, SeekAsc)).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` > ? ORDER BY `id`",
+			wantArgs: []driver.Value{10},
+		},
+		{
+			name:    "When query has WITH, then should be applied to the main query",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("users").Where("`active` = ?", true))).Table("cte").
+					Scopes(Seek([]string{"id"}, []interface{}{10}, SeekAsc)).Limit(20).Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` WHERE `active` = ?) SELECT * FROM `cte` WHERE `id` > ? ORDER BY `id` LIMIT ?",
+			wantArgs: []driver.Value{true, 10, 20},
+		},
+		{
+			name:    "When query has UNION, then should be wrapped in derived table",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("users"))).
+					Table("admins").Where("`active` = ?", true).Clauses(NewUnion(db.Table("cte"))).Order("`name`").
+					Scopes(Seek([]string{"id"}, []interface{}{10}, SeekDesc)).Limit(20).Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users`) SELECT * FROM (SELECT * FROM `admins` WHERE `active` = ? UNION SELECT * FROM `cte`) AS `exclause_seek` WHERE `id` < ? ORDER BY `id` DESC LIMIT ?",
+			wantArgs: []driver.Value{true, 10, 20},
+		},
+		{
+			name:    "When query has GROUP BY, then should be wrapped in derived table",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("orders").Select("`user_id`,SUM(`total`) AS `total`").Group("`user_id`").
+					Scopes(Seek([]string{"total", "user_id"}, []interface{}{100, 5}, SeekAsc)).Scan(nil)
+			},
+			want:     "SELECT * FROM (SELECT `user_id`,SUM(`total`) AS `total` FROM `orders` GROUP BY `user_id`) `exclause_seek` WHERE (`total` > ? OR (`total` = ? AND `user_id` > ?)) ORDER BY `total`,`user_id`",
+			wantArgs: []driver.Value{100, 100, 5},
+		},
+		{
+			name:    "When number of values doesn't match columns, then should be error",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Scopes(Seek([]string{"created_at", "id"}, []interface{}{10}, SeekAsc)).Scan(nil)
+			},
+			wantErr: gorm.ErrInvalidData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
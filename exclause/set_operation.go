@@ -0,0 +1,210 @@
+package exclause
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SetOperator is the operator of SetOperation
+type SetOperator string
+
+const (
+	// SetOperatorUnion combines rows of the statements
+	SetOperatorUnion SetOperator = "UNION"
+	// SetOperatorIntersect keeps rows that exist in all statements
+	SetOperatorIntersect SetOperator = "INTERSECT"
+	// SetOperatorExcept keeps rows of the first statement that don't exist in the others
+	SetOperatorExcept SetOperator = "EXCEPT"
+)
+
+// SetOperation is a set operation expression, that can be used where a subquery is valid.
+// Unlike Union, Intersect and Except clauses, it isn't attached to the outer statement.
+//
+//	// examples
+//	// UPDATE `users` SET `role`=? WHERE `id` IN (SELECT user_id FROM `admins` UNION SELECT user_id FROM `owners`)
+//	db.Table("users").Where("`id` IN (?)", exclause.SetOperation{
+//		Operator: exclause.SetOperatorUnion,
+//		Statements: []clause.Expression{
+//			exclause.Subquery{DB: db.Table("admins").Select("user_id")},
+//			exclause.Subquery{DB: db.Table("owners").Select("user_id")},
+//		},
+//	}).Update("role", "staff")
+//
+//	// SELECT user_id FROM `admins` UNION ALL SELECT user_id FROM `owners`
+//	exclause.SetOperation{Operator: exclause.SetOperatorUnion, All: true, Statements: ...}
+//
+//	// SELECT DISTINCT * FROM (SELECT user_id FROM `admins`) AS `exclause_left` WHERE NOT EXISTS (SELECT 1 FROM (SELECT user_id FROM `owners`) AS `exclause_right` WHERE `exclause_left`.`user_id` <=> `exclause_right`.`user_id`)
+//	exclause.SetOperation{Operator: exclause.SetOperatorExcept, EmulateOn: []string{"user_id"}, Statements: ...}
+type SetOperation struct {
+	Operator   SetOperator
+	All        bool
+	Statements []clause.Expression
+	// EmulateOn are the columns compared to emulate INTERSECT and EXCEPT with EXISTS and NOT EXISTS,
+	// for databases that don't support them such as MySQL before 8.0.31.
+	// It is ignored by UNION. With All, duplicated rows of the first statement are kept.
+	EmulateOn []string
+}
+
+// Build build set operation
+func (operation SetOperation) Build(builder clause.Builder) {
+	if len(operation.EmulateOn) > 0 && operation.Operator != SetOperatorUnion && len(operation.Statements) > 0 {
+		operation.buildEmulation(builder)
+		return
+	}
+
+	keyword := setOperatorKeyword(builder, operation.Operator)
+	for index, statement := range operation.Statements {
+		if index != 0 {
+			builder.WriteByte(' ')
+			builder.WriteString(keyword)
+			if operation.All {
+				builder.WriteString(" ALL")
+			}
+			builder.WriteByte(' ')
+		}
+		statement.Build(builder)
+	}
+}
+
+// UnionOf is easy to create new UNION SetOperation.
+// The queries can be *gorm.DB, raw SQL string or clause.Expression.
+//
+//	// examples
+//	// WITH `cte` AS (SELECT * FROM `admin_users` UNION SELECT * FROM `guest_users`) SELECT * FROM `cte`
+//	db.Clauses(exclause.NewWith("cte", exclause.UnionOf(db.Table("admin_users"), db.Table("guest_users")))).Table("cte").Scan(&users)
+//
+//	// SELECT * FROM `users` WHERE `id` IN (SELECT user_id FROM `admins` UNION SELECT user_id FROM `owners`)
+//	db.Table("users").Where("`id` IN (?)", exclause.UnionOf(db.Table("admins").Select("user_id"), db.Table("owners").Select("user_id"))).Scan(&users)
+//
+//	// SELECT * FROM (SELECT * FROM `admin_users` UNION SELECT * FROM `guest_users`) AS u
+//	db.Table("(?) AS u", exclause.UnionOf(db.Table("admin_users"), db.Table("guest_users"))).Scan(&users)
+func UnionOf(queries ...interface{}) SetOperation {
+	return newSetOperation(SetOperatorUnion, false, queries)
+}
+
+// UnionAllOf is easy to create new UNION ALL SetOperation
+//
+//	// examples
+//	// SELECT * FROM (SELECT * FROM `admin_users` UNION ALL SELECT * FROM `guest_users`) AS u
+//	db.Table("(?) AS u", exclause.UnionAllOf(db.Table("admin_users"), db.Table("guest_users"))).Scan(&users)
+func UnionAllOf(queries ...interface{}) SetOperation {
+	return newSetOperation(SetOperatorUnion, true, queries)
+}
+
+// IntersectOf is easy to create new INTERSECT SetOperation
+//
+//	// examples
+//	// SELECT * FROM `users` WHERE `id` IN (SELECT user_id FROM `admins` INTERSECT SELECT user_id FROM `owners`)
+//	db.Table("users").Where("`id` IN (?)", exclause.IntersectOf(db.Table("admins").Select("user_id"), db.Table("owners").Select("user_id"))).Scan(&users)
+func IntersectOf(queries ...interface{}) SetOperation {
+	return newSetOperation(SetOperatorIntersect, false, queries)
+}
+
+// ExceptOf is easy to create new EXCEPT SetOperation
+//
+//	// examples
+//	// SELECT * FROM `users` WHERE `id` IN (SELECT user_id FROM `admins` EXCEPT SELECT user_id FROM `owners`)
+//	db.Table("users").Where("`id` IN (?)", exclause.ExceptOf(db.Table("admins").Select("user_id"), db.Table("owners").Select("user_id"))).Scan(&users)
+func ExceptOf(queries ...interface{}) SetOperation {
+	return newSetOperation(SetOperatorExcept, false, queries)
+}
+
+// buildEmulation builds INTERSECT as EXISTS and EXCEPT as NOT EXISTS conditions on the first statement
+func (operation SetOperation) buildEmulation(builder clause.Builder) {
+	builder.WriteString("SELECT ")
+	if !operation.All {
+		builder.WriteString("DISTINCT ")
+	}
+	builder.WriteString("* FROM (")
+	operation.Statements[0].Build(builder)
+	builder.WriteString(") AS ")
+	builder.WriteQuoted(emulationLeftAlias)
+	builder.WriteString(" WHERE ")
+	for index, statement := range operation.Statements[1:] {
+		if index != 0 {
+			builder.WriteString(" AND ")
+		}
+		existsCondition{
+			Not:       operation.Operator == SetOperatorExcept,
+			Table:     emulationLeftAlias,
+			Columns:   operation.EmulateOn,
+			Statement: statement,
+		}.Build(builder)
+	}
+}
+
+const (
+	emulationLeftAlias  = "exclause_left"
+	emulationRightAlias = "exclause_right"
+)
+
+// existsCondition is a correlated [NOT] EXISTS condition that matches rows of Table with rows of Statement.
+// Columns are compared null safely, as set operations treat NULLs as equal.
+type existsCondition struct {
+	Not       bool
+	Table     string
+	Columns   []string
+	Statement clause.Expression
+}
+
+// Build build exists condition
+func (condition existsCondition) Build(builder clause.Builder) {
+	equal := " IS NOT DISTINCT FROM "
+	if dialectOf(builder) == dialectMySQL {
+		equal = " <=> "
+	}
+
+	if condition.Not {
+		builder.WriteString("NOT ")
+	}
+	builder.WriteString("EXISTS (SELECT 1 FROM (")
+	condition.Statement.Build(builder)
+	builder.WriteString(") AS ")
+	builder.WriteQuoted(emulationRightAlias)
+	builder.WriteString(" WHERE ")
+	for index, column := range condition.Columns {
+		if index != 0 {
+			builder.WriteString(" AND ")
+		}
+		builder.WriteQuoted(clause.Column{Table: condition.Table, Name: column})
+		builder.WriteString(equal)
+		builder.WriteQuoted(clause.Column{Table: emulationRightAlias, Name: column})
+	}
+	builder.WriteByte(')')
+}
+
+// emulateSetOperation adds [NOT] EXISTS conditions of the statements to the current query instead of INTERSECT or EXCEPT clause.
+// Duplicated rows of the current query are kept, so use Distinct to remove them.
+func emulateSetOperation(stmt *gorm.Statement, not bool, columns []string, statements []clause.Expression) {
+	exprs := make([]clause.Expression, len(statements))
+	for index, statement := range statements {
+		exprs[index] = existsCondition{
+			Not:       not,
+			Table:     clause.CurrentTable,
+			Columns:   columns,
+			Statement: statement,
+		}
+	}
+	stmt.AddClause(clause.Where{Exprs: exprs})
+}
+
+// setOperatorKeyword returns the keyword of the operator on the dialect.
+// Oracle names EXCEPT as MINUS.
+func setOperatorKeyword(builder clause.Builder, operator SetOperator) string {
+	if operator == SetOperatorExcept && dialectOf(builder) == dialectOracle {
+		return "MINUS"
+	}
+	return string(operator)
+}
+
+func newSetOperation(operator SetOperator, all bool, queries []interface{}) SetOperation {
+	statements := make([]clause.Expression, len(queries))
+	for index, query := range queries {
+		statements[index] = convertToClauseExpression(query)
+	}
+	return SetOperation{
+		Operator:   operator,
+		All:        all,
+		Statements: statements,
+	}
+}
@@ -0,0 +1,282 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	extraClausePlugin "github.com/WinterYukky/gorm-extra-clause-plugin"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestSetOperation_Update(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "When used in WHERE, then should be used as subquery",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where("`id` IN (?)", SetOperation{
+					Operator: SetOperatorUnion,
+					Statements: []clause.Expression{
+						Subquery{DB: db.Table("admins").Select("user_id").Where("`active` = ?", true)},
+						Subquery{DB: db.Table("owners").Select("user_id")},
+					},
+				}).Update("role", "staff")
+			},
+			want:     "UPDATE `users` SET `role`=? WHERE `id` IN (SELECT user_id FROM `admins` WHERE `active` = ? UNION SELECT user_id FROM `owners`)",
+			wantArgs: []driver.Value{"staff", true},
+		},
+		{
+			name: "When All is true, then should be used ALL keyword",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where("`id` IN (?)", SetOperation{
+					Operator: SetOperatorExcept,
+					All:      true,
+					Statements: []clause.Expression{
+						Subquery{DB: db.Table("admins").Select("user_id")},
+						clause.Expr{SQL: "SELECT `user_id` FROM `owners` WHERE `name` = ?", Vars: []interface{}{"WinterYukky"}},
+					},
+				}).Update("role", "staff")
+			},
+			want:     "UPDATE `users` SET `role`=? WHERE `id` IN (SELECT user_id FROM `admins` EXCEPT ALL SELECT `user_id` FROM `owners` WHERE `name` = ?)",
+			wantArgs: []driver.Value{"staff", "WinterYukky"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db, _ := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}))
+			db.Use(extraClausePlugin.New())
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+		})
+	}
+}
+
+func TestSetOperation_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "When used as CTE subquery, then should be used as CTE body",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", UnionOf(db.Table("admin_users").Where("`name` = ?", "WinterYukky"), db.Table("guest_users")))).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `admin_users` WHERE `name` = ? UNION SELECT * FROM `guest_users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When used as CTE subquery with NewCTE, then should be used as CTE body",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", IntersectOf(db.Table("admin_users"), "SELECT * FROM `guest_users`"))}}).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `admin_users` INTERSECT SELECT * FROM `guest_users`) SELECT /*+ NO_MERGE(`cte`) */ * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When used in WHERE, then should be used as subquery",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where("`id` IN (?)", ExceptOf(db.Table("admins").Select("user_id"), db.Table("owners").Select("user_id").Where("`id` > ?", 10))).Where("`name` = ?", "WinterYukky").Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` IN (SELECT user_id FROM `admins` EXCEPT SELECT user_id FROM `owners` WHERE `id` > ?) AND `name` = ?",
+			wantArgs: []driver.Value{10, "WinterYukky"},
+		},
+		{
+			name: "When used as derived table, then should be used as table",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("(?) AS u", UnionAllOf(db.Table("admin_users"), db.Table("guest_users"))).Where("`u`.`name` = ?", "WinterYukky").Scan(nil)
+			},
+			want:     "SELECT * FROM (SELECT * FROM `admin_users` UNION ALL SELECT * FROM `guest_users`) AS u WHERE `u`.`name` = ?",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When has more than two queries, then should be used all queries",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("(?) AS u", UnionOf(db.Table("admin_users"), db.Table("guest_users"), db.Table("general_users"))).Scan(nil)
+			},
+			want:     "SELECT * FROM (SELECT * FROM `admin_users` UNION SELECT * FROM `guest_users` UNION SELECT * FROM `general_users`) AS u",
+			wantArgs: []driver.Value{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db, _ := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}))
+			db.Use(extraClausePlugin.New())
+			mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+		})
+	}
+}
+
+func TestSetOperation_Dialect(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name:    "When dialect is oracle, then EXCEPT should be rendered as MINUS",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where("`id` IN (?)", ExceptOf(db.Table("admins").Select("user_id"), db.Table("owners").Select("user_id"))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` IN (SELECT user_id FROM `admins` MINUS SELECT user_id FROM `owners`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is oracle, then INTERSECT should not be changed",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where("`id` IN (?)", IntersectOf(db.Table("admins").Select("user_id"), db.Table("owners").Select("user_id"))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` IN (SELECT user_id FROM `admins` INTERSECT SELECT user_id FROM `owners`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When EXCEPT is emulated on mysql, then should be used NOT EXISTS with null safe equal",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				except := ExceptOf(db.Table("admins").Select("user_id"), db.Table("owners").Select("user_id").Where("`active` = ?", true))
+				except.EmulateOn = []string{"user_id"}
+				return db.Table("users").Where("`id` IN (?)", except).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` IN (SELECT DISTINCT * FROM (SELECT user_id FROM `admins`) AS `exclause_left` WHERE NOT EXISTS (SELECT 1 FROM (SELECT user_id FROM `owners` WHERE `active` = ?) AS `exclause_right` WHERE `exclause_left`.`user_id` <=> `exclause_right`.`user_id`))",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When INTERSECT is emulated with multiple statements, then should be used EXISTS for each statement",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				intersect := IntersectOf(db.Table("admins"), db.Table("owners"), db.Table("guests"))
+				intersect.EmulateOn = []string{"id", "name"}
+				return db.Table("(?) AS u", intersect).Scan(nil)
+			},
+			want: "SELECT * FROM (SELECT DISTINCT * FROM (SELECT * FROM `admins`) AS `exclause_left` WHERE " +
+				"EXISTS (SELECT 1 FROM (SELECT * FROM `owners`) AS `exclause_right` WHERE `exclause_left`.`id` IS NOT DISTINCT FROM `exclause_right`.`id` AND `exclause_left`.`name` IS NOT DISTINCT FROM `exclause_right`.`name`) AND " +
+				"EXISTS (SELECT 1 FROM (SELECT * FROM `guests`) AS `exclause_right` WHERE `exclause_left`.`id` IS NOT DISTINCT FROM `exclause_right`.`id` AND `exclause_left`.`name` IS NOT DISTINCT FROM `exclause_right`.`name`)) AS u",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When emulated with All, then should not be used DISTINCT",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("(?) AS u", SetOperation{
+					Operator:   SetOperatorExcept,
+					All:        true,
+					EmulateOn:  []string{"id"},
+					Statements: []clause.Expression{Subquery{DB: db.Table("admins")}, Subquery{DB: db.Table("owners")}},
+				}).Scan(nil)
+			},
+			want:     "SELECT * FROM (SELECT * FROM (SELECT * FROM `admins`) AS `exclause_left` WHERE NOT EXISTS (SELECT 1 FROM (SELECT * FROM `owners`) AS `exclause_right` WHERE `exclause_left`.`id` <=> `exclause_right`.`id`)) AS u",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When UNION has EmulateOn, then should not be emulated",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				union := UnionOf(db.Table("admins"), db.Table("owners"))
+				union.EmulateOn = []string{"id"}
+				return db.Table("(?) AS u", union).Scan(nil)
+			},
+			want:     "SELECT * FROM (SELECT * FROM `admins` UNION SELECT * FROM `owners`) AS u",
+			wantArgs: []driver.Value{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestUnionOf(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}))
+	db = db.Table("users")
+	tests := []struct {
+		name    string
+		queries []interface{}
+		want    SetOperation
+	}{
+		{
+			name:    "When query is *gorm.DB, then statement is exclause.Subquery",
+			queries: []interface{}{db, db},
+			want: SetOperation{
+				Operator:   SetOperatorUnion,
+				Statements: []clause.Expression{Subquery{DB: db}, Subquery{DB: db}},
+			},
+		},
+		{
+			name:    "When query is string, then statement is clause.Expr",
+			queries: []interface{}{"SELECT * FROM `users`", clause.Expr{SQL: "SELECT * FROM `admins`"}},
+			want: SetOperation{
+				Operator:   SetOperatorUnion,
+				Statements: []clause.Expression{clause.Expr{SQL: "SELECT * FROM `users`"}, clause.Expr{SQL: "SELECT * FROM `admins`"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnionOf(tt.queries...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnionOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package exclause
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils"
+)
+
+// ErrIncompatibleSetOperation is returned when branches of UNION, INTERSECT or EXCEPT select incompatible columns.
+// It is validated only when the plugin is used with extraClausePlugin.WithSetOperationValidation.
+var ErrIncompatibleSetOperation = fmt.Errorf("%w: set operation branches are not compatible", gorm.ErrInvalidData)
+
+// Validate validates that the statement and the union branches select compatible columns
+func (union Union) Validate(stmt *gorm.Statement) error {
+	return validateSetOperation(stmt, union.Name(), union.Statements)
+}
+
+// Validate validates that the statement and the intersect branches select compatible columns
+func (intersect Intersect) Validate(stmt *gorm.Statement) error {
+	return validateSetOperation(stmt, intersect.Name(), intersect.Statements)
+}
+
+// Validate validates that the statement and the except branches select compatible columns
+func (except Except) Validate(stmt *gorm.Statement) error {
+	return validateSetOperation(stmt, except.Name(), except.Statements)
+}
+
+// selectedColumn is a column selected by a branch of set operation
+type selectedColumn struct {
+	name string
+	// family is the kind of data type, or empty when it is unknown
+	family string
+}
+
+// validateSetOperation compares column counts and type families of the branches with the first branch that has known columns.
+// The statement itself is the first branch.
+func validateSetOperation(stmt *gorm.Statement, operator string, statements []clause.Expression) error {
+	var (
+		reference      []selectedColumn
+		referenceIndex int
+	)
+	if columns, ok := statementColumns(stmt); ok {
+		reference, referenceIndex = columns, 1
+	}
+	for index, statement := range statements {
+		columns, ok := expressionColumns(statement)
+		if !ok {
+			continue
+		}
+		branch := index + 2
+		if reference == nil {
+			reference, referenceIndex = columns, branch
+			continue
+		}
+		if len(columns) != len(reference) {
+			return fmt.Errorf("%w: %s branch %d selects %d columns, but branch %d selects %d columns",
+				ErrIncompatibleSetOperation, operator, branch, len(columns), referenceIndex, len(reference))
+		}
+		for i, column := range columns {
+			want := reference[i]
+			if column.family != "" && want.family != "" && column.family != want.family {
+				return fmt.Errorf("%w: %s branch %d column %d (%s) is %s, but branch %d column %d (%s) is %s",
+					ErrIncompatibleSetOperation, operator, branch, i+1, column.name, column.family, referenceIndex, i+1, want.name, want.family)
+			}
+		}
+	}
+	return nil
+}
+
+// expressionColumns returns the selected columns of a branch, which is known only for queries built by *gorm.DB
+func expressionColumns(expression clause.Expression) ([]selectedColumn, bool) {
+	switch v := expression.(type) {
+	case Subquery:
+		if v.DB != nil {
+			return statementColumns(v.DB.Statement)
+		}
+	case clause.Expr:
+		// e.g. NewUnion("ALL ?", db)
+		if len(v.Vars) == 1 {
+			if db, ok := v.Vars[0].(*gorm.DB); ok {
+				switch strings.ToUpper(strings.TrimSpace(v.SQL)) {
+				case "?", "ALL ?", "DISTINCT ?":
+					return statementColumns(db.Statement)
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// statementColumns returns the columns selected by Select, or the fields of model when nothing is selected
+func statementColumns(stmt *gorm.Statement) ([]selectedColumn, bool) {
+	if c, ok := stmt.Clauses["SELECT"]; ok && c.Expression != nil {
+		// raw SQL of Select with args, or Count
+		return nil, false
+	}
+
+	s := statementSchema(stmt)
+	if len(stmt.Selects) > 0 {
+		columns := []selectedColumn{}
+		for _, selected := range stmt.Selects {
+			for _, name := range splitSelect(selected) {
+				if strings.Contains(name, "*") {
+					return nil, false
+				}
+				columns = append(columns, selectedColumn{name: name, family: columnFamily(s, name)})
+			}
+		}
+		return columns, true
+	}
+
+	if s == nil {
+		return nil, false
+	}
+	columns := []selectedColumn{}
+	for _, dbName := range s.DBNames {
+		if slices.Contains(stmt.Omits, dbName) {
+			continue
+		}
+		columns = append(columns, selectedColumn{name: dbName, family: dataTypeFamily(s.FieldsByDBName[dbName].DataType)})
+	}
+	return columns, true
+}
+
+var schemaCaches = &sync.Map{}
+
+// schemaCache returns the schema cache for the naming strategy,
+// as the schemas parsed with other naming strategies have other column names
+func schemaCache(namer schema.Namer) *sync.Map {
+	if !reflect.ValueOf(namer).Comparable() {
+		return &sync.Map{}
+	}
+	cache, _ := schemaCaches.LoadOrStore(namer, &sync.Map{})
+	return cache.(*sync.Map)
+}
+
+// statementSchema parses the model of statement without modifying the statement
+func statementSchema(stmt *gorm.Statement) *schema.Schema {
+	model := stmt.Model
+	if model == nil {
+		model = stmt.Dest
+	}
+	if model == nil {
+		return nil
+	}
+	s, err := schema.Parse(model, schemaCache(stmt.NamingStrategy), stmt.NamingStrategy)
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+// splitSelect splits selected columns such as "id, name" by comma outside of parentheses
+func splitSelect(selected string) []string {
+	names := []string{}
+	depth, start := 0, 0
+	for i, c := range selected {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				names = append(names, strings.TrimSpace(selected[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(names, strings.TrimSpace(selected[start:]))
+}
+
+// columnFamily returns type family of the selected column when it is a field of the schema
+func columnFamily(s *schema.Schema, name string) string {
+	if s == nil {
+		return ""
+	}
+	fields := strings.FieldsFunc(name, utils.IsValidDBNameChar)
+	if len(fields) != 1 {
+		return ""
+	}
+	name = fields[0]
+	if index := strings.LastIndexByte(name, '.'); index >= 0 {
+		name = name[index+1:]
+	}
+	if field := s.LookUpField(name); field != nil {
+		return dataTypeFamily(field.DataType)
+	}
+	return ""
+}
+
+// dataTypeFamily groups data types which databases can combine in set operations
+func dataTypeFamily(dataType schema.DataType) string {
+	switch dataType {
+	case schema.Int, schema.Uint, schema.Float:
+		return "number"
+	case schema.Bool:
+		return "bool"
+	case schema.String:
+		return "string"
+	case schema.Time:
+		return "time"
+	case schema.Bytes:
+		return "bytes"
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package exclause
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	extraClausePlugin "github.com/WinterYukky/gorm-extra-clause-plugin"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type validationUser struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time
+}
+
+type validationAdmin struct {
+	ID   uint
+	Name string
+}
+
+func TestSetOperation_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "When models have different column counts, then should be error",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&validationUser{}).Clauses(NewUnion(db.Model(&validationAdmin{}))).Find(&[]validationUser{})
+			},
+			wantErr: true,
+		},
+		{
+			name: "When selects have different column counts, then should be error",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Select("id", "name").Clauses(NewIntersect(db.Table("admins").Select("id"))).Scan(&[]map[string]interface{}{})
+			},
+			wantErr: true,
+		},
+		{
+			name: "When comma separated select has different column count, then should be error",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Select("id, name, COALESCE(nickname, name)").Clauses(NewExcept("ALL ?", db.Table("admins").Select("id", "name"))).Scan(&[]map[string]interface{}{})
+			},
+			wantErr: true,
+		},
+		{
+			name: "When column types are different, then should be error",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&validationUser{}).Select("id", "name").Clauses(NewUnion(db.Model(&validationAdmin{}).Select("name", "id"))).Find(&[]validationUser{})
+			},
+			wantErr: true,
+		},
+		{
+			name: "When the first branch is unknown, then other branches should be compared",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewUnion(db.Model(&validationAdmin{}))).Clauses(NewUnion(db.Model(&validationUser{}))).Scan(&[]map[string]interface{}{})
+			},
+			wantErr: true,
+		},
+		{
+			name: "When columns are compatible, then should be executed",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&validationUser{}).Select("id, name").Clauses(NewUnion(db.Model(&validationAdmin{}))).Find(&[]validationUser{})
+			},
+			want: "SELECT id, name FROM `validation_users` UNION SELECT * FROM `validation_admins`",
+		},
+		{
+			name: "When branch is raw SQL, then should not be validated",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Select("id", "name").Clauses(NewUnion("SELECT `id` FROM `admins`")).Scan(&[]map[string]interface{}{})
+			},
+			want: "SELECT id,name FROM `users` UNION SELECT `id` FROM `admins`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db, _ := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}))
+			db.Use(extraClausePlugin.New(extraClausePlugin.WithSetOperationValidation()))
+			if !tt.wantErr {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+
+			db = tt.operation(db)
+			if tt.wantErr != errors.Is(db.Error, ErrIncompatibleSetOperation) {
+				t.Errorf("error is %v, wantErr %v", db.Error, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(db.Error, gorm.ErrInvalidData) {
+				t.Errorf("error is %v, want %v", db.Error, gorm.ErrInvalidData)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSetOperation_Validate_Disabled(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}))
+	db.Use(extraClausePlugin.New())
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `validation_users` UNION SELECT * FROM `validation_admins`")).WillReturnRows(sqlmock.NewRows([]string{}))
+
+	db = db.Model(&validationUser{}).Clauses(NewUnion(db.Model(&validationAdmin{}))).Find(&[]validationUser{})
+	if db.Error != nil {
+		t.Errorf(db.Error.Error())
+	}
+}
+
+func TestSchemaCache(t *testing.T) {
+	parse := func(namer schema.Namer) *schema.Schema {
+		s, err := schema.Parse(&validationUser{}, schemaCache(namer), namer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	if got := parse(schema.NamingStrategy{}).Table; got != "validation_users" {
+		t.Errorf("table is %v, want %v", got, "validation_users")
+	}
+	if got := parse(schema.NamingStrategy{TablePrefix: "t_"}).Table; got != "t_validation_users" {
+		t.Errorf("table is %v, want %v", got, "t_validation_users")
+	}
+	if schemaCache(schema.NamingStrategy{}) != schemaCache(schema.NamingStrategy{}) {
+		t.Errorf("schema cache of the same naming strategy should be shared")
+	}
+}
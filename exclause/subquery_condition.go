@@ -0,0 +1,132 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm/clause"
+)
+
+// ExistsExpr is EXISTS (subquery) condition, and NOT EXISTS with Not.
+// The subquery can be *gorm.DB, Subquery, set operations such as UnionOf, or other clause.Expression.
+//
+//	// examples
+//	// SELECT * FROM `users` WHERE EXISTS (SELECT * FROM `orders` WHERE `orders`.`user_id` = `users`.`id`)
+//	db.Table("users").Where(exclause.Exists(db.Table("orders").Where("`orders`.`user_id` = `users`.`id`"))).Scan(&users)
+//
+//	// SELECT * FROM `users` WHERE NOT EXISTS (SELECT * FROM `orders` WHERE `orders`.`user_id` = `users`.`id`)
+//	db.Table("users").Where(clause.Not(exclause.Exists(db.Table("orders").Where("`orders`.`user_id` = `users`.`id`")))).Scan(&users)
+type ExistsExpr struct {
+	Not      bool
+	Subquery interface{}
+}
+
+// Build build EXISTS condition
+func (exists ExistsExpr) Build(builder clause.Builder) {
+	if exists.Not {
+		builder.WriteString("NOT ")
+	}
+	builder.WriteString("EXISTS ")
+	writeSubquery(builder, exists.Subquery)
+}
+
+// NegationBuild build negated EXISTS condition for clause.Not
+func (exists ExistsExpr) NegationBuild(builder clause.Builder) {
+	exists.Not = !exists.Not
+	exists.Build(builder)
+}
+
+// Exists is easy to create new EXISTS condition
+func Exists(subquery interface{}) ExistsExpr {
+	return ExistsExpr{Subquery: subquery}
+}
+
+// NotExists is easy to create new NOT EXISTS condition
+func NotExists(subquery interface{}) ExistsExpr {
+	return ExistsExpr{Not: true, Subquery: subquery}
+}
+
+// QuantifiedExpr is the comparison with ANY or ALL of subquery, such as `price` > ALL (subquery).
+// SQLite has no ANY and ALL, so = ANY is written as IN and <> ALL is written as NOT IN there, and others are error.
+//
+//	// examples
+//	// SELECT * FROM `products` WHERE `price` > ALL (SELECT `price` FROM `products` WHERE `category` = 'book')
+//	db.Table("products").Where(exclause.All("price", ">", db.Table("products").Select("`price`").Where("`category` = ?", "book"))).Scan(&products)
+type QuantifiedExpr struct {
+	Column   interface{}
+	Operator string
+	// Quantifier is ANY or ALL
+	Quantifier string
+	Subquery   interface{}
+}
+
+// Build build quantified comparison
+func (quantified QuantifiedExpr) Build(builder clause.Builder) {
+	if dialect := dialectOf(builder); dialect == dialectSQLite {
+		switch {
+		case quantified.Quantifier == "ANY" && quantified.Operator == "=":
+			InExpr{Column: quantified.Column, Subquery: quantified.Subquery}.Build(builder)
+		case quantified.Quantifier == "ALL" && (quantified.Operator == "<>" || quantified.Operator == "!="):
+			InExpr{Column: quantified.Column, Not: true, Subquery: quantified.Subquery}.Build(builder)
+		default:
+			builder.AddError(fmt.Errorf("%w: %s %s on %s", ErrUnsupportedDialect, quantified.Operator, quantified.Quantifier, dialect))
+		}
+		return
+	}
+
+	builder.WriteQuoted(quantified.Column)
+	builder.WriteByte(' ')
+	builder.WriteString(quantified.Operator)
+	builder.WriteByte(' ')
+	builder.WriteString(quantified.Quantifier)
+	builder.WriteByte(' ')
+	writeSubquery(builder, quantified.Subquery)
+}
+
+// Any is easy to create new comparison with ANY of subquery
+func Any(column interface{}, operator string, subquery interface{}) QuantifiedExpr {
+	return QuantifiedExpr{Column: column, Operator: operator, Quantifier: "ANY", Subquery: subquery}
+}
+
+// All is easy to create new comparison with ALL of subquery
+func All(column interface{}, operator string, subquery interface{}) QuantifiedExpr {
+	return QuantifiedExpr{Column: column, Operator: operator, Quantifier: "ALL", Subquery: subquery}
+}
+
+// InExpr is IN (subquery) condition, and NOT IN with Not
+//
+//	// examples
+//	// SELECT * FROM `users` WHERE `id` IN (SELECT `user_id` FROM `admins` UNION SELECT `user_id` FROM `owners`)
+//	db.Table("users").Where(exclause.In("id", exclause.UnionOf(db.Table("admins").Select("`user_id`"), db.Table("owners").Select("`user_id`")))).Scan(&users)
+type InExpr struct {
+	Column   interface{}
+	Not      bool
+	Subquery interface{}
+}
+
+// Build build IN condition
+func (in InExpr) Build(builder clause.Builder) {
+	builder.WriteQuoted(in.Column)
+	if in.Not {
+		builder.WriteString(" NOT")
+	}
+	builder.WriteString(" IN ")
+	writeSubquery(builder, in.Subquery)
+}
+
+// NegationBuild build negated IN condition for clause.Not
+func (in InExpr) NegationBuild(builder clause.Builder) {
+	in.Not = !in.Not
+	in.Build(builder)
+}
+
+// In is easy to create new IN condition
+func In(column interface{}, subquery interface{}) InExpr {
+	return InExpr{Column: column, Subquery: subquery}
+}
+
+// writeSubquery writes the subquery in parentheses
+func writeSubquery(builder clause.Builder, subquery interface{}) {
+	builder.WriteByte('(')
+	builder.AddVar(builder, subquery)
+	builder.WriteByte(')')
+}
@@ -0,0 +1,153 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestSubqueryCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When Exists is given, then should be EXISTS subquery",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where(Exists(db.Table("orders").Where("`orders`.`user_id` = `users`.`id`").Where("`total` > ?", 100))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE EXISTS (SELECT * FROM `orders` WHERE `orders`.`user_id` = `users`.`id` AND `total` > ?)",
+			wantArgs: []driver.Value{100},
+		},
+		{
+			name:    "When NotExists is given with Subquery, then should be NOT EXISTS subquery",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where(NotExists(Subquery{DB: db.Table("orders").Where("`orders`.`user_id` = `users`.`id`")})).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE NOT EXISTS (SELECT * FROM `orders` WHERE `orders`.`user_id` = `users`.`id`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When Exists is negated with clause.Not, then should be NOT EXISTS",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where(clause.Not(Exists(db.Table("orders")), clause.Eq{Column: "name", Value: "WinterYukky"})).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE (NOT EXISTS (SELECT * FROM `orders`) AND `name` <> ?)",
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name:    "When conditions are composed with clause.Or, then should be OR",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where(clause.Or(
+					Exists(db.Table("admins").Where("`admins`.`user_id` = `users`.`id`")),
+					In("id", db.Table("owners").Select("`user_id`")),
+				)).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE (EXISTS (SELECT * FROM `admins` WHERE `admins`.`user_id` = `users`.`id`) OR `id` IN (SELECT `user_id` FROM `owners`))",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When In has set operation, then should be IN set operation",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where(In("id", UnionOf(db.Table("admins").Select("`user_id`"), db.Table("owners").Select("`user_id`")))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` IN (SELECT `user_id` FROM `admins` UNION SELECT `user_id` FROM `owners`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When In is negated with clause.Not, then should be NOT IN",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Not(In(clause.Column{Table: "users", Name: "id"}, db.Table("banned").Select("`user_id`"))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `users`.`id` NOT IN (SELECT `user_id` FROM `banned`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When subquery has WITH, then should be written in the subquery",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where(In("id", db.Clauses(NewWith("cte", db.Table("admins").Where("`active` = ?", true))).Table("cte").Select("`user_id`"))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` IN (WITH `cte` AS (SELECT * FROM `admins` WHERE `active` = ?) SELECT `user_id` FROM `cte`)",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When All is given, then should be ALL subquery",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("products").Where(All("price", ">", db.Table("products").Select("`price`").Where("`category` = ?", "book"))).Scan(nil)
+			},
+			want:     "SELECT * FROM `products` WHERE `price` > ALL (SELECT `price` FROM `products` WHERE `category` = ?)",
+			wantArgs: []driver.Value{"book"},
+		},
+		{
+			name:    "When Any is negated with clause.Not, then should be NOT ANY",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("products").Where(clause.Not(Any("price", "<", db.Table("discounts").Select("`price`")))).Scan(nil)
+			},
+			want:     "SELECT * FROM `products` WHERE NOT `price` < ANY (SELECT `price` FROM `discounts`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is sqlite and = ANY is given, then should be IN",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where(Any("id", "=", db.Table("admins").Select("`user_id`"))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` IN (SELECT `user_id` FROM `admins`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is sqlite and <> ALL is given, then should be NOT IN",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Where(All("id", "<>", db.Table("admins").Select("`user_id`"))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` NOT IN (SELECT `user_id` FROM `admins`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is sqlite and > ALL is given, then should be error",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("products").Where(All("price", ">", db.Table("products").Select("`price`"))).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
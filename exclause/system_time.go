@@ -0,0 +1,140 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SystemTimeKind is the kind of period of SystemTime
+type SystemTimeKind int
+
+const (
+	// SystemTimeAsOf queries the rows that were valid at Start
+	SystemTimeAsOf SystemTimeKind = iota
+	// SystemTimeBetween queries the rows that were valid from Start to End, including the rows started at End
+	SystemTimeBetween
+	// SystemTimeFromTo queries the rows that were valid from Start to End, excluding the rows started at End
+	SystemTimeFromTo
+	// SystemTimeContainedIn queries the rows that were opened and closed within Start and End
+	SystemTimeContainedIn
+	// SystemTimeAll queries all rows of current and history
+	SystemTimeAll
+)
+
+// SystemTime is FOR SYSTEM_TIME clause of system-versioned temporal tables, that is written after the first table of FROM clause.
+// It is supported by SQL Server and MariaDB (mysql dialect), and SystemTimeContainedIn is supported by SQL Server only.
+// It works in CTE and set operation subqueries as well, because the clause belongs to the subquery.
+//
+//	// examples
+//	// SELECT * FROM `users` FOR SYSTEM_TIME AS OF '2023-01-01 00:00:00' WHERE `id` = 1
+//	db.Table("users").Clauses(exclause.NewSystemTimeAsOf(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))).Where("`id` = ?", 1).Scan(&users)
+//
+//	// SELECT * FROM `users` FOR SYSTEM_TIME BETWEEN '2023-01-01 00:00:00' AND '2024-01-01 00:00:00'
+//	db.Table("users").Clauses(exclause.NewSystemTimeBetween(start, end)).Scan(&users)
+//
+//	// SELECT * FROM `users` FOR SYSTEM_TIME ALL
+//	db.Table("users").Clauses(exclause.NewSystemTimeAll()).Scan(&users)
+type SystemTime struct {
+	Kind  SystemTimeKind
+	Start interface{}
+	// End is the end of the period, it is not used by SystemTimeAsOf and SystemTimeAll
+	End interface{}
+}
+
+// Name system time clause name
+func (systemTime SystemTime) Name() string {
+	return "SYSTEM_TIME"
+}
+
+// Build build system time clause
+func (systemTime SystemTime) Build(builder clause.Builder) {
+	switch dialect := dialectOf(builder); {
+	case dialect != dialectSQLServer && dialect != dialectMySQL:
+		builder.AddError(fmt.Errorf("%w: FOR SYSTEM_TIME on %s", ErrUnsupportedDialect, dialect))
+		return
+	case systemTime.Kind == SystemTimeContainedIn && dialect != dialectSQLServer:
+		builder.AddError(fmt.Errorf("%w: FOR SYSTEM_TIME CONTAINED IN on %s", ErrUnsupportedDialect, dialect))
+		return
+	}
+
+	builder.WriteString("FOR SYSTEM_TIME ")
+	switch systemTime.Kind {
+	case SystemTimeAsOf:
+		builder.WriteString("AS OF ")
+		builder.AddVar(builder, systemTime.Start)
+	case SystemTimeBetween:
+		builder.WriteString("BETWEEN ")
+		builder.AddVar(builder, systemTime.Start)
+		builder.WriteString(" AND ")
+		builder.AddVar(builder, systemTime.End)
+	case SystemTimeFromTo:
+		builder.WriteString("FROM ")
+		builder.AddVar(builder, systemTime.Start)
+		builder.WriteString(" TO ")
+		builder.AddVar(builder, systemTime.End)
+	case SystemTimeContainedIn:
+		builder.WriteString("CONTAINED IN (")
+		builder.AddVar(builder, systemTime.Start)
+		builder.WriteString(", ")
+		builder.AddVar(builder, systemTime.End)
+		builder.WriteByte(')')
+	case SystemTimeAll:
+		builder.WriteString("ALL")
+	default:
+		builder.AddError(fmt.Errorf("%w: unknown system time kind %d", gorm.ErrInvalidData, systemTime.Kind))
+	}
+}
+
+// ModifyStatement attach the system time to the FROM table
+func (systemTime SystemTime) ModifyStatement(stmt *gorm.Statement) {
+	modifyFrom(stmt, func(from *suffixedFrom) {
+		from.SystemTime = systemTime
+	})
+}
+
+// NewSystemTimeAsOf is easy to create new SystemTime of SystemTimeAsOf
+//
+//	// examples
+//	// SELECT * FROM `users` FOR SYSTEM_TIME AS OF '2023-01-01 00:00:00'
+//	db.Table("users").Clauses(exclause.NewSystemTimeAsOf(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))).Scan(&users)
+func NewSystemTimeAsOf(at interface{}) SystemTime {
+	return SystemTime{Kind: SystemTimeAsOf, Start: at}
+}
+
+// NewSystemTimeBetween is easy to create new SystemTime of SystemTimeBetween
+//
+//	// examples
+//	// SELECT * FROM `users` FOR SYSTEM_TIME BETWEEN '2023-01-01 00:00:00' AND '2024-01-01 00:00:00'
+//	db.Table("users").Clauses(exclause.NewSystemTimeBetween(start, end)).Scan(&users)
+func NewSystemTimeBetween(start, end interface{}) SystemTime {
+	return SystemTime{Kind: SystemTimeBetween, Start: start, End: end}
+}
+
+// NewSystemTimeFromTo is easy to create new SystemTime of SystemTimeFromTo
+//
+//	// examples
+//	// SELECT * FROM `users` FOR SYSTEM_TIME FROM '2023-01-01 00:00:00' TO '2024-01-01 00:00:00'
+//	db.Table("users").Clauses(exclause.NewSystemTimeFromTo(start, end)).Scan(&users)
+func NewSystemTimeFromTo(start, end interface{}) SystemTime {
+	return SystemTime{Kind: SystemTimeFromTo, Start: start, End: end}
+}
+
+// NewSystemTimeContainedIn is easy to create new SystemTime of SystemTimeContainedIn
+//
+//	// examples
+//	// SELECT * FROM `users` FOR SYSTEM_TIME CONTAINED IN ('2023-01-01 00:00:00', '2024-01-01 00:00:00')
+//	db.Table("users").Clauses(exclause.NewSystemTimeContainedIn(start, end)).Scan(&users)
+func NewSystemTimeContainedIn(start, end interface{}) SystemTime {
+	return SystemTime{Kind: SystemTimeContainedIn, Start: start, End: end}
+}
+
+// NewSystemTimeAll is easy to create new SystemTime of SystemTimeAll
+//
+//	// examples
+//	// SELECT * FROM `users` FOR SYSTEM_TIME ALL
+//	db.Table("users").Clauses(exclause.NewSystemTimeAll()).Scan(&users)
+func NewSystemTimeAll() SystemTime {
+	return SystemTime{Kind: SystemTimeAll}
+}
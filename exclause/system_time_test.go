@@ -0,0 +1,161 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestSystemTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When kind is as of, then should be written FOR SYSTEM_TIME AS OF after the table",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewSystemTimeAsOf("2023-01-01")).Where("`id` = ?", 1).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` FOR SYSTEM_TIME AS OF ? WHERE `id` = ?",
+			wantArgs: []driver.Value{"2023-01-01", 1},
+		},
+		{
+			name:    "When kind is between, then should be written FOR SYSTEM_TIME BETWEEN",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewSystemTimeBetween("2023-01-01", "2024-01-01")).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` FOR SYSTEM_TIME BETWEEN ? AND ?",
+			wantArgs: []driver.Value{"2023-01-01", "2024-01-01"},
+		},
+		{
+			name:    "When kind is from to, then should be written FOR SYSTEM_TIME FROM TO",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewSystemTimeFromTo("2023-01-01", "2024-01-01")).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` FOR SYSTEM_TIME FROM ? TO ?",
+			wantArgs: []driver.Value{"2023-01-01", "2024-01-01"},
+		},
+		{
+			name:    "When kind is contained in, then should be written FOR SYSTEM_TIME CONTAINED IN",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewSystemTimeContainedIn("2023-01-01", "2024-01-01")).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` FOR SYSTEM_TIME CONTAINED IN (?, ?)",
+			wantArgs: []driver.Value{"2023-01-01", "2024-01-01"},
+		},
+		{
+			name:    "When kind is all and clause is given before table, then should be written after the table",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewSystemTimeAll()).Table("users").Scan(nil)
+			},
+			want:     "SELECT * FROM `users` FOR SYSTEM_TIME ALL",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When query has joins, then should be written before joins",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Joins("JOIN `groups` ON `groups`.`id` = `users`.`group_id`").Clauses(NewSystemTimeAll()).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` FOR SYSTEM_TIME ALL JOIN `groups` ON `groups`.`id` = `users`.`group_id`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When query has TableSample, then should be written before TABLESAMPLE",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewTableSample(TableSampleSystem, 10), NewSystemTimeAll()).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` FOR SYSTEM_TIME ALL TABLESAMPLE SYSTEM (10 PERCENT)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When CTE subquery has SystemTime, then should be written in the subquery",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("users").Clauses(NewSystemTimeAsOf("2023-01-01")))).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` FOR SYSTEM_TIME AS OF ?) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{"2023-01-01"},
+		},
+		{
+			name:    "When set operation branch has SystemTime, then should be written in the branch",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewUnion(db.Table("users").Clauses(NewSystemTimeAsOf("2023-01-01")))).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` UNION SELECT * FROM `users` FOR SYSTEM_TIME AS OF ?",
+			wantArgs: []driver.Value{"2023-01-01"},
+		},
+		{
+			name:    "When kind is contained in on mysql, then should be error",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewSystemTimeContainedIn("2023-01-01", "2024-01-01")).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When dialect is postgres, then should be error",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewSystemTimeAll()).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewSystemTime(t *testing.T) {
+	tests := []struct {
+		name string
+		got  SystemTime
+		want SystemTime
+	}{
+		{name: "AsOf", got: NewSystemTimeAsOf(1), want: SystemTime{Kind: SystemTimeAsOf, Start: 1}},
+		{name: "Between", got: NewSystemTimeBetween(1, 2), want: SystemTime{Kind: SystemTimeBetween, Start: 1, End: 2}},
+		{name: "FromTo", got: NewSystemTimeFromTo(1, 2), want: SystemTime{Kind: SystemTimeFromTo, Start: 1, End: 2}},
+		{name: "ContainedIn", got: NewSystemTimeContainedIn(1, 2), want: SystemTime{Kind: SystemTimeContainedIn, Start: 1, End: 2}},
+		{name: "All", got: NewSystemTimeAll(), want: SystemTime{Kind: SystemTimeAll}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
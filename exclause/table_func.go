@@ -0,0 +1,174 @@
+package exclause
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TableFunc is table-valued function that is used as table, such as unnest, generate_series and JSON_TABLE.
+// It is written as the function call with the alias and column names, and JSON_TABLE has column definitions in COLUMNS.
+// It can be used in Table, Joins, and CTE subquery with Table.
+//
+//	// examples
+//	// SELECT * FROM unnest(ARRAY[1,2,3]) WITH ORDINALITY AS `t` (`id`,`ord`)
+//	ids := exclause.NewUnnest("t", clause.Expr{SQL: "ARRAY[1,2,3]"}, "id", "ord")
+//	ids.WithOrdinality = true
+//	db.Scopes(ids.From()).Scan(&rows)
+//
+//	// SELECT `users`.* FROM `users` JOIN generate_series(1,10) AS `s` ON `users`.`id` = `s`.`s`
+//	series := exclause.NewGenerateSeries("s", 1, 10)
+//	db.Table("users").Select("`users`.*").Joins("JOIN ? ON `users`.`id` = ?", series, series.Col("s")).Scan(&users)
+//
+//	// SELECT * FROM JSON_TABLE(`orders`.`items`,'$[*]' COLUMNS (`ord` FOR ORDINALITY,`sku` VARCHAR(32) PATH '$.sku')) AS `i`
+//	items := exclause.NewJSONTable("i", clause.Column{Table: "orders", Name: "items"}, "$[*]",
+//		exclause.TableFuncColumn{Name: "ord", Ordinality: true},
+//		exclause.TableFuncColumn{Name: "sku", Type: "VARCHAR(32)", Path: "$.sku"},
+//	)
+//	db.Table("orders").Joins("CROSS JOIN ?", items).Scan(&rows)
+//
+//	// WITH `ids` AS (SELECT * FROM unnest(ARRAY[1,2,3]) AS `t` (`id`)) SELECT * FROM `ids`
+//	db.Clauses(exclause.NewWith("ids", db.Scopes(exclause.NewUnnest("t", clause.Expr{SQL: "ARRAY[1,2,3]"}, "id").From()))).Table("ids").Scan(&rows)
+type TableFunc struct {
+	Name string
+	// Args are the arguments of the function, clause.Expression is written as is and others are bind variables
+	Args           []interface{}
+	WithOrdinality bool
+	Alias          string
+	Columns        []TableFuncColumn
+}
+
+// TableFuncColumn is the column of TableFunc
+type TableFuncColumn struct {
+	Name string
+	// Type is the type of column, required in JSON_TABLE and functions returning record such as json_to_recordset
+	Type string
+	// Path is the JSON path of JSON_TABLE column
+	Path string
+	// Ordinality is the row number column of JSON_TABLE
+	Ordinality bool
+}
+
+// Build build table function
+func (tableFunc TableFunc) Build(builder clause.Builder) {
+	jsonTable := strings.EqualFold(tableFunc.Name, "JSON_TABLE")
+	builder.WriteString(tableFunc.Name)
+	builder.WriteByte('(')
+	for index, arg := range tableFunc.Args {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+		builder.AddVar(builder, arg)
+	}
+	if jsonTable {
+		builder.WriteString(" COLUMNS (")
+		for index, column := range tableFunc.Columns {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(column.Name)
+			if column.Ordinality {
+				builder.WriteString(" FOR ORDINALITY")
+				continue
+			}
+			builder.WriteByte(' ')
+			builder.WriteString(column.Type)
+			if column.Path != "" {
+				builder.WriteString(" PATH ")
+				writeLiteral(builder, column.Path)
+			}
+		}
+		builder.WriteByte(')')
+	}
+	builder.WriteByte(')')
+	if tableFunc.WithOrdinality {
+		builder.WriteString(" WITH ORDINALITY")
+	}
+	if tableFunc.Alias == "" {
+		return
+	}
+	writeAlias(builder, dialectOf(builder), tableFunc.Alias)
+	if !jsonTable && len(tableFunc.Columns) > 0 {
+		builder.WriteString(" (")
+		for index, column := range tableFunc.Columns {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(column.Name)
+			if column.Type != "" {
+				builder.WriteByte(' ')
+				builder.WriteString(column.Type)
+			}
+		}
+		builder.WriteByte(')')
+	}
+}
+
+// Table returns the table function as table of its alias
+func (tableFunc TableFunc) Table() clause.Table {
+	return clause.Table{Name: tableFunc.Alias}
+}
+
+// Col returns the column of the table function
+func (tableFunc TableFunc) Col(name string) clause.Column {
+	return clause.Column{Table: tableFunc.Alias, Name: name}
+}
+
+// From returns the scope that selects from the table function
+//
+//	// SELECT * FROM generate_series(1,10) AS `s`
+//	db.Scopes(exclause.NewGenerateSeries("s", 1, 10).From()).Scan(&rows)
+func (tableFunc TableFunc) From() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tx := db.Table("?", tableFunc)
+		tx.Statement.Table = tableFunc.Alias
+		return tx
+	}
+}
+
+// NewUnnest is easy to create new unnest TableFunc
+//
+//	// examples
+//	// SELECT * FROM unnest(ARRAY[1,2,3]) AS `t` (`id`)
+//	db.Scopes(exclause.NewUnnest("t", clause.Expr{SQL: "ARRAY[1,2,3]"}, "id").From()).Scan(&rows)
+func NewUnnest(alias string, array interface{}, columns ...string) TableFunc {
+	return TableFunc{Name: "unnest", Args: []interface{}{array}, Alias: alias, Columns: newTableFuncColumns(columns)}
+}
+
+// NewGenerateSeries is easy to create new generate_series TableFunc, its column is named as alias
+//
+//	// examples
+//	// SELECT * FROM generate_series(1,10,2) AS `s`
+//	db.Scopes(exclause.NewGenerateSeries("s", 1, 10, 2).From()).Scan(&rows)
+func NewGenerateSeries(alias string, start, stop interface{}, step ...interface{}) TableFunc {
+	return TableFunc{Name: "generate_series", Args: append([]interface{}{start, stop}, step...), Alias: alias}
+}
+
+// NewJSONTable is easy to create new JSON_TABLE TableFunc
+//
+//	// examples
+//	// SELECT * FROM JSON_TABLE(`orders`.`items`,'$[*]' COLUMNS (`sku` VARCHAR(32) PATH '$.sku')) AS `i`
+//	db.Table("orders").Joins("CROSS JOIN ?", exclause.NewJSONTable("i", clause.Column{Table: "orders", Name: "items"}, "$[*]",
+//		exclause.TableFuncColumn{Name: "sku", Type: "VARCHAR(32)", Path: "$.sku"})).Scan(&rows)
+func NewJSONTable(alias string, doc interface{}, path string, columns ...TableFuncColumn) TableFunc {
+	return TableFunc{Name: "JSON_TABLE", Args: []interface{}{doc, literal{Value: path}}, Alias: alias, Columns: columns}
+}
+
+func newTableFuncColumns(names []string) []TableFuncColumn {
+	columns := make([]TableFuncColumn, len(names))
+	for index, name := range names {
+		columns[index] = TableFuncColumn{Name: name}
+	}
+	return columns
+}
+
+// literal is the value written as SQL literal, for the arguments that don't accept bind variables such as the path of JSON_TABLE
+type literal struct {
+	Value interface{}
+}
+
+// Build build literal
+func (l literal) Build(builder clause.Builder) {
+	writeLiteral(builder, l.Value)
+}
@@ -0,0 +1,113 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestTableFunc(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name:    "When unnest with ordinality is used in From, then should be written with alias and columns",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				ids := NewUnnest("t", clause.Expr{SQL: "?::int[]", Vars: []interface{}{"{1,2,3}"}}, "id", "ord")
+				ids.WithOrdinality = true
+				return db.Scopes(ids.From()).Where(clause.Gt{Column: ids.Col("ord"), Value: 1}).Scan(nil)
+			},
+			want:     "SELECT * FROM unnest(?::int[]) WITH ORDINALITY AS `t` (`id`,`ord`) WHERE `t`.`ord` > ?",
+			wantArgs: []driver.Value{"{1,2,3}", 1},
+		},
+		{
+			name:    "When generate_series is used in joins, then should be joined",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				series := NewGenerateSeries("s", 1, 10, 2)
+				return db.Table("users").Select("`users`.*").Joins("JOIN ? ON `users`.`id` = ?", series, series.Col("s")).Scan(nil)
+			},
+			want:     "SELECT `users`.* FROM `users` JOIN generate_series(?,?,?) AS `s` ON `users`.`id` = `s`.`s`",
+			wantArgs: []driver.Value{1, 10, 2},
+		},
+		{
+			name:    "When record function has typed columns, then should be written column definitions",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Scopes(TableFunc{
+					Name:    "json_to_recordset",
+					Args:    []interface{}{`[{"id":1}]`},
+					Alias:   "r",
+					Columns: []TableFuncColumn{{Name: "id", Type: "int"}, {Name: "name", Type: "text"}},
+				}.From()).Scan(nil)
+			},
+			want:     "SELECT * FROM json_to_recordset(?) AS `r` (`id` int,`name` text)",
+			wantArgs: []driver.Value{`[{"id":1}]`},
+		},
+		{
+			name:    "When JSON_TABLE is used in joins, then should be written COLUMNS with literals",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				items := NewJSONTable("i", clause.Column{Table: "orders", Name: "items"}, "$[*]",
+					TableFuncColumn{Name: "ord", Ordinality: true},
+					TableFuncColumn{Name: "sku", Type: "VARCHAR(32)", Path: "$.sku"},
+				)
+				return db.Table("orders").Select("?", items.Col("sku")).Joins("CROSS JOIN ?", items).Scan(nil)
+			},
+			want:     "SELECT `i`.`sku` FROM `orders` CROSS JOIN JSON_TABLE(`orders`.`items`,'$[*]' COLUMNS (`ord` FOR ORDINALITY,`sku` VARCHAR(32) PATH '$.sku')) AS `i`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is oracle, then should be written alias without AS",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Scopes(NewJSONTable("i", clause.Column{Name: "doc"}, "$.items[*]", TableFuncColumn{Name: "sku", Type: "VARCHAR2(32)", Path: "$.sku"}).From()).Scan(nil)
+			},
+			want:     "SELECT * FROM JSON_TABLE(`doc`,'$.items[*]' COLUMNS (`sku` VARCHAR2(32) PATH '$.sku')) `i`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When table function is used in CTE subquery, then should be selected in the subquery",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("ids", db.Scopes(NewUnnest("t", clause.Expr{SQL: "ARRAY[1,2,3]"}, "id").From()))).Table("ids").Scan(nil)
+			},
+			want:     "WITH `ids` AS (SELECT * FROM unnest(ARRAY[1,2,3]) AS `t` (`id`)) SELECT * FROM `ids`",
+			wantArgs: []driver.Value{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			db = tt.operation(db)
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestTableFunc_Table(t *testing.T) {
+	tableFunc := NewGenerateSeries("s", 1, 10)
+	if got, want := tableFunc.Table(), (clause.Table{Name: "s"}); got != want {
+		t.Errorf("Table() = %v, want %v", got, want)
+	}
+	want := TableFunc{Name: "generate_series", Args: []interface{}{1, 10}, Alias: "s"}
+	if !reflect.DeepEqual(tableFunc, want) {
+		t.Errorf("NewGenerateSeries() = %v, want %v", tableFunc, want)
+	}
+}
@@ -0,0 +1,137 @@
+package exclause
+
+import (
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TableSampleMethod is the sampling method of TableSample
+type TableSampleMethod int
+
+const (
+	// TableSampleBernoulli samples each row with the percentage
+	TableSampleBernoulli TableSampleMethod = iota
+	// TableSampleSystem samples each block of rows with the percentage, it is faster but less random
+	TableSampleSystem
+)
+
+// TableSample is TABLESAMPLE clause that samples rows of the FROM table.
+// It is written after the first table of FROM clause in PostgreSQL and SQL Server syntax, and SAMPLE clause on Oracle.
+// SQL Server supports TableSampleSystem only.
+// MySQL and SQLite have no TABLESAMPLE, so rows are sampled by ORDER BY RANDOM() LIMIT with Limit instead,
+// and the statement can't have other ORDER BY there.
+//
+//	// examples
+//	// PostgreSQL: SELECT * FROM `users` TABLESAMPLE BERNOULLI (10) REPEATABLE (42)
+//	// SQL Server: SELECT * FROM `users` TABLESAMPLE SYSTEM (10 PERCENT) REPEATABLE (42)
+//	// Oracle:     SELECT * FROM `users` SAMPLE (10) SEED (42)
+//	seed := 42
+//	db.Table("users").Clauses(exclause.TableSample{Percent: 10, Seed: &seed}).Scan(&users)
+//
+//	// MySQL: SELECT * FROM `users` ORDER BY RAND() LIMIT 100
+//	db.Table("users").Clauses(exclause.TableSample{Percent: 10, Limit: 100}).Scan(&users)
+type TableSample struct {
+	Method  TableSampleMethod
+	Percent float64
+	// Seed makes the sample repeatable
+	Seed *int
+	// Limit is the number of sampled rows on MySQL and SQLite
+	Limit int
+}
+
+// Name table sample clause name
+func (sample TableSample) Name() string {
+	return "TABLESAMPLE"
+}
+
+// Build build table sample clause
+func (sample TableSample) Build(builder clause.Builder) {
+	percent := strconv.FormatFloat(sample.Percent, 'f', -1, 64)
+	switch dialect := dialectOf(builder); dialect {
+	case dialectOracle:
+		builder.WriteString("SAMPLE ")
+		if sample.Method == TableSampleSystem {
+			builder.WriteString("BLOCK ")
+		}
+		builder.WriteString("(" + percent + ")")
+		if sample.Seed != nil {
+			builder.WriteString(" SEED (" + strconv.Itoa(*sample.Seed) + ")")
+		}
+	case dialectSQLServer:
+		if sample.Method != TableSampleSystem {
+			builder.AddError(fmt.Errorf("%w: TABLESAMPLE BERNOULLI on %s", ErrUnsupportedDialect, dialect))
+			return
+		}
+		builder.WriteString("TABLESAMPLE SYSTEM (" + percent + " PERCENT)")
+		sample.buildRepeatable(builder)
+	default:
+		builder.WriteString("TABLESAMPLE ")
+		if sample.Method == TableSampleSystem {
+			builder.WriteString("SYSTEM")
+		} else {
+			builder.WriteString("BERNOULLI")
+		}
+		builder.WriteString(" (" + percent + ")")
+		sample.buildRepeatable(builder)
+	}
+}
+
+func (sample TableSample) buildRepeatable(builder clause.Builder) {
+	if sample.Seed != nil {
+		builder.WriteString(" REPEATABLE (" + strconv.Itoa(*sample.Seed) + ")")
+	}
+}
+
+// ModifyStatement attach the sample to the FROM table, or order by random and limit on MySQL and SQLite
+func (sample TableSample) ModifyStatement(stmt *gorm.Statement) {
+	switch dialect := statementDialect(stmt); dialect {
+	case dialectMySQL, dialectSQLite:
+		random := "RANDOM()"
+		if dialect == dialectMySQL {
+			random = "RAND()"
+			if sample.Seed != nil {
+				random = "RAND(" + strconv.Itoa(*sample.Seed) + ")"
+			}
+		} else if sample.Seed != nil {
+			stmt.AddError(fmt.Errorf("%w: TABLESAMPLE REPEATABLE on %s", ErrUnsupportedDialect, dialect))
+			return
+		}
+		if sample.Limit <= 0 {
+			stmt.AddError(fmt.Errorf("%w: TABLESAMPLE without Limit on %s", ErrUnsupportedDialect, dialect))
+			return
+		}
+		limit := sample.Limit
+		stmt.AddClause(clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: random, Raw: true}}}})
+		c := stmt.Clauses["ORDER BY"]
+		c.Builder = buildSampleOrderBy
+		stmt.Clauses["ORDER BY"] = c
+		stmt.AddClause(clause.Limit{Limit: &limit})
+	default:
+		modifyFrom(stmt, func(from *suffixedFrom) {
+			from.Sample = sample
+		})
+	}
+}
+
+// NewTableSample is easy to create new TableSample
+//
+//	// examples
+//	// SELECT * FROM `users` TABLESAMPLE SYSTEM (1)
+//	db.Table("users").Clauses(exclause.NewTableSample(exclause.TableSampleSystem, 1)).Scan(&users)
+func NewTableSample(method TableSampleMethod, percent float64) TableSample {
+	return TableSample{Method: method, Percent: percent}
+}
+
+// buildSampleOrderBy builds the random order of TableSample.
+// The order is checked on build, as ORDER BY can be added after TableSample.
+func buildSampleOrderBy(c clause.Clause, builder clause.Builder) {
+	if orderBy, ok := c.Expression.(clause.OrderBy); !ok || len(orderBy.Columns) != 1 || orderBy.Expression != nil {
+		builder.AddError(fmt.Errorf("%w: TABLESAMPLE with ORDER BY on %s", ErrUnsupportedDialect, dialectOf(builder)))
+		return
+	}
+	c.Builder = nil
+	c.Build(builder)
+}
@@ -0,0 +1,163 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestTableSample(t *testing.T) {
+	seed := 42
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When dialect is postgres, then should be written TABLESAMPLE after the table",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(TableSample{Percent: 10, Seed: &seed}).Where("`age` > ?", 20).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` TABLESAMPLE BERNOULLI (10) REPEATABLE (42) WHERE `age` > ?",
+			wantArgs: []driver.Value{20},
+		},
+		{
+			name:    "When clause is given before table, then should be written after the table",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewTableSample(TableSampleSystem, 0.5)).Table("users").Scan(nil)
+			},
+			want:     "SELECT * FROM `users` TABLESAMPLE SYSTEM (0.5)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When query has joins, then should be written before joins",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Joins("JOIN `groups` ON `groups`.`id` = `users`.`group_id`").Clauses(NewTableSample(TableSampleBernoulli, 10)).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` TABLESAMPLE BERNOULLI (10) JOIN `groups` ON `groups`.`id` = `users`.`group_id`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When CTE subquery has TableSample, then should be written in the subquery",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("users").Clauses(NewTableSample(TableSampleBernoulli, 10)))).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` TABLESAMPLE BERNOULLI (10)) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is sqlserver, then should be written with PERCENT",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(TableSample{Method: TableSampleSystem, Percent: 10, Seed: &seed}).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` TABLESAMPLE SYSTEM (10 PERCENT) REPEATABLE (42)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is sqlserver and method is bernoulli, then should be error",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewTableSample(TableSampleBernoulli, 10)).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When dialect is oracle, then should be written SAMPLE",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(TableSample{Method: TableSampleSystem, Percent: 10, Seed: &seed}).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` SAMPLE BLOCK (10) SEED (42)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is mysql, then should be ordered by random",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(TableSample{Percent: 10, Seed: &seed, Limit: 100}).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY RAND(42) LIMIT ?",
+			wantArgs: []driver.Value{100},
+		},
+		{
+			name:    "When dialect is sqlite, then should be ordered by random",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(TableSample{Percent: 10, Limit: 100}).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` ORDER BY RANDOM() LIMIT ?",
+			wantArgs: []driver.Value{100},
+		},
+		{
+			name:    "When dialect is sqlite and seed is given, then should be error",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(TableSample{Percent: 10, Seed: &seed, Limit: 100}).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When dialect is mysql and query has ORDER BY, then should be error",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Order("id").Clauses(TableSample{Percent: 10, Limit: 100}).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When dialect is sqlite and ORDER BY is given after TableSample, then should be error",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(TableSample{Percent: 10, Limit: 100}).Order("id").Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When dialect is mysql and limit is not given, then should be error",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Clauses(NewTableSample(TableSampleBernoulli, 10)).Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewTableSample(t *testing.T) {
+	want := TableSample{Method: TableSampleSystem, Percent: 10}
+	if got := NewTableSample(TableSampleSystem, 10); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTableSample() = %v, want %v", got, want)
+	}
+}
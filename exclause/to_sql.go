@@ -0,0 +1,41 @@
+package exclause
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrPluginNotInstalled is returned by ToSQL when ExtraClausePlugin is not installed to the database
+var ErrPluginNotInstalled = fmt.Errorf("%w: ExtraClausePlugin is not installed", gorm.ErrInvalidDB)
+
+// ToSQL returns the SQL and the bound vars of the statement built by queryFn, without executing it.
+// The statement is built by DryRun session, so the clauses are ordered by ExtraClausePlugin as well as executed statements.
+// Query, Create, Update and Delete statements are built by their finisher methods such as Find, Create, Update and Delete,
+// and the statement without finisher method is built as Row statement like Row and Rows.
+//
+//	// examples
+//	sql, vars, err := exclause.ToSQL(db, func(tx *gorm.DB) *gorm.DB {
+//		return tx.Clauses(exclause.NewWith("cte", tx.Table("users").Where("`name` = ?", "WinterYukky"))).Table("cte").Find(&users)
+//	})
+//	// sql:  WITH `cte` AS (SELECT * FROM `users` WHERE `name` = ?) SELECT * FROM `cte`
+//	// vars: []interface{}{"WinterYukky"}
+//
+//	sql, vars, err := exclause.ToSQL(db, func(tx *gorm.DB) *gorm.DB {
+//		return tx.Table("users").Clauses(exclause.NewUnion(tx.Table("admins"))).Select("`id`")
+//	})
+//	// sql:  SELECT `id` FROM `users` UNION SELECT * FROM `admins`
+func ToSQL(db *gorm.DB, queryFn func(tx *gorm.DB) *gorm.DB) (string, []interface{}, error) {
+	if _, ok := db.Plugins["ExtraClausePlugin"]; !ok {
+		return "", nil, ErrPluginNotInstalled
+	}
+
+	tx := queryFn(db.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}))
+	if tx.Error == nil && tx.Statement.SQL.Len() == 0 {
+		tx = tx.Callback().Row().Execute(tx)
+	}
+	if tx.Error != nil {
+		return "", nil, tx.Error
+	}
+	return tx.Statement.SQL.String(), tx.Statement.Vars, nil
+}
@@ -0,0 +1,129 @@
+package exclause
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestToSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  string
+		queryFn  func(tx *gorm.DB) *gorm.DB
+		want     string
+		wantVars []interface{}
+		wantErr  error
+	}{
+		{
+			name:    "When query has CTE, then should return the SQL and vars",
+			dialect: dialectMySQL,
+			queryFn: func(tx *gorm.DB) *gorm.DB {
+				return tx.Clauses(NewWith("cte", tx.Table("users").Where("`name` = ?", "WinterYukky"))).Table("cte").Where("`age` > ?", 20).Find(&[]insertUser{})
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` WHERE `name` = ?) SELECT * FROM `cte` WHERE `age` > ?",
+			wantVars: []interface{}{"WinterYukky", 20},
+		},
+		{
+			name:    "When statement has no finisher, then should be built as row statement",
+			dialect: dialectPostgres,
+			queryFn: func(tx *gorm.DB) *gorm.DB {
+				return tx.Table("users").Select("`id`").Clauses(NewUnion(tx.Table("admins").Select("`id`"))).Order("`id`").Clauses(NewFetch(10))
+			},
+			want:     "SELECT `id` FROM `users` UNION SELECT `id` FROM `admins` ORDER BY `id` FETCH FIRST ? ROWS ONLY",
+			wantVars: []interface{}{10},
+		},
+		{
+			name:    "When statement is count, then should return the wrapped count",
+			dialect: dialectMySQL,
+			queryFn: func(tx *gorm.DB) *gorm.DB {
+				var count int64
+				return tx.Table("users").Clauses(NewUnion(tx.Table("admins"))).Count(&count)
+			},
+			want: "SELECT count(*) FROM (SELECT * FROM `users` UNION SELECT * FROM `admins`) AS `t`",
+		},
+		{
+			name:    "When statement is create, then should return the SQL and vars",
+			dialect: dialectSQLite,
+			queryFn: func(tx *gorm.DB) *gorm.DB {
+				return tx.Clauses(NewInsertModifier(InsertIgnore)).Create(&insertUser{Name: "WinterYukky"})
+			},
+			want:     "INSERT OR IGNORE INTO `insert_users` (`name`) VALUES (?)",
+			wantVars: []interface{}{"WinterYukky"},
+		},
+		{
+			name:    "When statement is update, then should return the SQL and vars",
+			dialect: dialectPostgres,
+			queryFn: func(tx *gorm.DB) *gorm.DB {
+				return tx.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Where("`profiles`.`active` = ?", true).Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `users` SET `name`=`profiles`.`name` FROM `profiles` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`active` = ?",
+			wantVars: []interface{}{true},
+		},
+		{
+			name:    "When statement is delete, then should return the SQL and vars",
+			dialect: dialectMySQL,
+			queryFn: func(tx *gorm.DB) *gorm.DB {
+				return tx.Clauses(NewComment("cleanup")).Table("users").Where("`name` = ?", "WinterYukky").Delete(nil)
+			},
+			want:     "/* cleanup */ DELETE FROM `users` WHERE `name` = ?",
+			wantVars: []interface{}{"WinterYukky"},
+		},
+		{
+			name:    "When statement has build error, then should return the error",
+			dialect: dialectMySQL,
+			queryFn: func(tx *gorm.DB) *gorm.DB {
+				return tx.Table("users").Clauses(NewFetch(10)).Find(&[]insertUser{})
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			got, gotVars, err := ToSQL(db, tt.queryFn)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error is %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ToSQL() sql = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotVars, tt.wantVars) {
+				t.Errorf("ToSQL() vars = %v, want %v", gotVars, tt.wantVars)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestToSQL_PluginNotInstalled(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}))
+	_, _, err = ToSQL(db, func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("users").Find(&[]insertUser{})
+	})
+	if !errors.Is(err, ErrPluginNotInstalled) {
+		t.Errorf("error is %v, want %v", err, ErrPluginNotInstalled)
+	}
+}
@@ -0,0 +1,132 @@
+package exclause
+
+import (
+	"gorm.io/gorm/clause"
+)
+
+// Unpivot is the reverse of Pivot, that turns UnpivotColumns into rows of NameColumn and ValueColumn.
+// Rows whose value is NULL are excluded as native UNPIVOT does.
+// It is rendered as UNPIVOT on SQL Server and Oracle, LATERAL VALUES on PostgreSQL and UNION ALL on MySQL and SQLite.
+// It can be used as CTE subquery or derived table.
+//
+//	// examples
+//	unpivot := exclause.Unpivot{
+//		Source:         "sales",
+//		Columns:        []string{"year"},
+//		NameColumn:     "month",
+//		ValueColumn:    "amount",
+//		UnpivotColumns: []string{"m1", "m2"},
+//	}
+//
+//	// MySQL, SQLite:
+//	// WITH `cte` AS (SELECT `year`,'m1' AS `month`,`m1` AS `amount` FROM `sales` AS `exclause_source` WHERE `m1` IS NOT NULL
+//	// UNION ALL SELECT `year`,'m2' AS `month`,`m2` AS `amount` FROM `sales` AS `exclause_source` WHERE `m2` IS NOT NULL) SELECT * FROM `cte`
+//	//
+//	// PostgreSQL:
+//	// WITH `cte` AS (SELECT `exclause_source`.`year`,`exclause_unpivot`.`month`,`exclause_unpivot`.`amount` FROM `sales` AS `exclause_source`
+//	// CROSS JOIN LATERAL (VALUES ('m1',`exclause_source`.`m1`),('m2',`exclause_source`.`m2`)) AS `exclause_unpivot` (`month`,`amount`)
+//	// WHERE `exclause_unpivot`.`amount` IS NOT NULL) SELECT * FROM `cte`
+//	//
+//	// SQL Server:
+//	// WITH `cte` AS (SELECT `year`,`month`,`amount` FROM `sales` AS `exclause_source` UNPIVOT (`amount` FOR `month` IN (`m1`,`m2`)) AS `exclause_unpivot`) SELECT * FROM `cte`
+//	db.Clauses(exclause.NewWith("cte", unpivot)).Table("cte").Scan(&sales)
+type Unpivot struct {
+	// Source is table name, clause.Table, *gorm.DB or clause.Expression of subquery
+	Source interface{}
+	// Columns are kept as is
+	Columns        []string
+	NameColumn     string
+	ValueColumn    string
+	UnpivotColumns []string
+}
+
+// Build build unpivot
+func (unpivot Unpivot) Build(builder clause.Builder) {
+	switch dialect := dialectOf(builder); dialect {
+	case dialectSQLServer, dialectOracle:
+		builder.WriteString("SELECT ")
+		for _, column := range unpivot.Columns {
+			builder.WriteQuoted(column)
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(unpivot.NameColumn)
+		builder.WriteByte(',')
+		builder.WriteQuoted(unpivot.ValueColumn)
+		builder.WriteString(" FROM ")
+		buildPivotSource(builder, dialect, unpivot.Source)
+		builder.WriteString(" UNPIVOT (")
+		builder.WriteQuoted(unpivot.ValueColumn)
+		builder.WriteString(" FOR ")
+		builder.WriteQuoted(unpivot.NameColumn)
+		builder.WriteString(" IN (")
+		for index, column := range unpivot.UnpivotColumns {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(column)
+			if dialect == dialectOracle {
+				// the name is upper case column name without alias on Oracle
+				builder.WriteString(" AS ")
+				writeLiteral(builder, column)
+			}
+		}
+		builder.WriteString("))")
+		if dialect == dialectSQLServer {
+			writeAlias(builder, dialect, unpivotValueAlias)
+		}
+	case dialectPostgres:
+		builder.WriteString("SELECT ")
+		for _, column := range unpivot.Columns {
+			builder.WriteQuoted(clause.Column{Table: pivotSourceAlias, Name: column})
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(clause.Column{Table: unpivotValueAlias, Name: unpivot.NameColumn})
+		builder.WriteByte(',')
+		builder.WriteQuoted(clause.Column{Table: unpivotValueAlias, Name: unpivot.ValueColumn})
+		builder.WriteString(" FROM ")
+		buildPivotSource(builder, dialect, unpivot.Source)
+		builder.WriteString(" CROSS JOIN LATERAL (VALUES ")
+		for index, column := range unpivot.UnpivotColumns {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteByte('(')
+			builder.AddVar(builder, column)
+			builder.WriteByte(',')
+			builder.WriteQuoted(clause.Column{Table: pivotSourceAlias, Name: column})
+			builder.WriteByte(')')
+		}
+		builder.WriteString(") AS ")
+		builder.WriteQuoted(unpivotValueAlias)
+		builder.WriteString(" (")
+		builder.WriteQuoted(unpivot.NameColumn)
+		builder.WriteByte(',')
+		builder.WriteQuoted(unpivot.ValueColumn)
+		builder.WriteString(") WHERE ")
+		builder.WriteQuoted(clause.Column{Table: unpivotValueAlias, Name: unpivot.ValueColumn})
+		builder.WriteString(" IS NOT NULL")
+	default:
+		for index, column := range unpivot.UnpivotColumns {
+			if index > 0 {
+				builder.WriteString(" UNION ALL ")
+			}
+			builder.WriteString("SELECT ")
+			for _, c := range unpivot.Columns {
+				builder.WriteQuoted(c)
+				builder.WriteByte(',')
+			}
+			builder.AddVar(builder, column)
+			builder.WriteString(" AS ")
+			builder.WriteQuoted(unpivot.NameColumn)
+			builder.WriteByte(',')
+			builder.WriteQuoted(column)
+			builder.WriteString(" AS ")
+			builder.WriteQuoted(unpivot.ValueColumn)
+			builder.WriteString(" FROM ")
+			buildPivotSource(builder, dialect, unpivot.Source)
+			builder.WriteString(" WHERE ")
+			builder.WriteQuoted(column)
+			builder.WriteString(" IS NOT NULL")
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestUnpivot(t *testing.T) {
+	unpivot := Unpivot{
+		Source:         "sales",
+		Columns:        []string{"year"},
+		NameColumn:     "month",
+		ValueColumn:    "amount",
+		UnpivotColumns: []string{"m1", "m2"},
+	}
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name:    "When dialect is mysql, then should be UNION ALL",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", unpivot)).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT `year`,? AS `month`,`m1` AS `amount` FROM `sales` AS `exclause_source` WHERE `m1` IS NOT NULL UNION ALL SELECT `year`,? AS `month`,`m2` AS `amount` FROM `sales` AS `exclause_source` WHERE `m2` IS NOT NULL) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{"m1", "m2"},
+		},
+		{
+			name:    "When source is subquery on sqlite, then should be UNION ALL of the subquery",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Table("(?) AS `u`", Unpivot{
+					Source:         db.Table("sales").Where("`year` = ?", 2024),
+					NameColumn:     "month",
+					ValueColumn:    "amount",
+					UnpivotColumns: []string{"m1", "m2"},
+				}).Scan(nil)
+			},
+			want:     "SELECT * FROM (SELECT ? AS `month`,`m1` AS `amount` FROM (SELECT * FROM `sales` WHERE `year` = ?) AS `exclause_source` WHERE `m1` IS NOT NULL UNION ALL SELECT ? AS `month`,`m2` AS `amount` FROM (SELECT * FROM `sales` WHERE `year` = ?) AS `exclause_source` WHERE `m2` IS NOT NULL) AS `u`",
+			wantArgs: []driver.Value{"m1", 2024, "m2", 2024},
+		},
+		{
+			name:    "When dialect is postgres, then should be LATERAL VALUES",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", unpivot)).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT `exclause_source`.`year`,`exclause_unpivot`.`month`,`exclause_unpivot`.`amount` FROM `sales` AS `exclause_source` CROSS JOIN LATERAL (VALUES (?,`exclause_source`.`m1`),(?,`exclause_source`.`m2`)) AS `exclause_unpivot` (`month`,`amount`) WHERE `exclause_unpivot`.`amount` IS NOT NULL) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{"m1", "m2"},
+		},
+		{
+			name:    "When dialect is sqlserver, then should be UNPIVOT",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", unpivot)).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT `year`,`month`,`amount` FROM `sales` AS `exclause_source` UNPIVOT (`amount` FOR `month` IN (`m1`,`m2`)) AS `exclause_unpivot`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is oracle, then should be UNPIVOT with names",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", unpivot)).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT `year`,`month`,`amount` FROM `sales` `exclause_source` UNPIVOT (`amount` FOR `month` IN (`m1` AS 'm1',`m2` AS 'm2'))) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			db = tt.operation(db)
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
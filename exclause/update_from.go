@@ -0,0 +1,257 @@
+package exclause
+
+import (
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FromTable is a table joined to the target table of UPDATE or DELETE statement
+type FromTable struct {
+	// Table is a table name, CTE name, clause.Table or aliased derived table such as clause.Expr{SQL: "(?) AS `t`"}
+	Table interface{}
+	// On is the join condition. It is added to WHERE clause, so the statement doesn't need other WHERE conditions.
+	On []clause.Expression
+}
+
+// UpdateFrom is UPDATE ... FROM clause, or UPDATE ... CROSS JOIN on MySQL
+//
+//	// examples
+//	// PostgreSQL: UPDATE `users` SET `name`=`profiles`.`name` FROM `profiles` WHERE `users`.`id` = `profiles`.`user_id`
+//	// MySQL:      UPDATE `users` CROSS JOIN `profiles` SET `users`.`name`=`profiles`.`name` WHERE `users`.`id` = `profiles`.`user_id`
+//	// SQL Server: UPDATE `users` SET `name`=`profiles`.`name` FROM `users` CROSS JOIN `profiles` WHERE `users`.`id` = `profiles`.`user_id`
+//	db.Clauses(exclause.NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).Table("users").Update("name", gorm.Expr("`profiles`.`name`"))
+//
+//	// UPDATE `users` SET `name`=`cte`.`name` FROM `cte` WHERE `users`.`id` = `cte`.`id`
+//	db.Clauses(exclause.NewWith("cte", db.Table("profiles"))).
+//		Clauses(exclause.NewUpdateFrom("cte", clause.Expr{SQL: "`users`.`id` = `cte`.`id`"})).
+//		Table("users").Update("name", gorm.Expr("`cte`.`name`"))
+//
+//	// UPDATE `users` SET `name`=`p`.`name` FROM (SELECT * FROM `profiles`) AS `p` WHERE `users`.`id` = `p`.`user_id`
+//	db.Clauses(exclause.NewUpdateFrom(clause.Expr{SQL: "(?) AS `p`", Vars: []interface{}{db.Table("profiles")}}, clause.Expr{SQL: "`users`.`id` = `p`.`user_id`"})).
+//		Table("users").Update("name", gorm.Expr("`p`.`name`"))
+type UpdateFrom struct {
+	Tables []FromTable
+}
+
+// Name update from clause name
+func (from UpdateFrom) Name() string {
+	return "UPDATE FROM"
+}
+
+// Build build update from clause
+func (from UpdateFrom) Build(builder clause.Builder) {
+	switch dialectOf(builder) {
+	case dialectMySQL:
+		buildCrossJoinedTables(builder, from.Tables)
+		buildQualifiedSet(builder)
+	case dialectSQLServer:
+		builder.WriteString("FROM ")
+		builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+		builder.WriteByte(' ')
+		buildCrossJoinedTables(builder, from.Tables)
+	default:
+		builder.WriteString("FROM ")
+		buildTableList(builder, from.Tables)
+	}
+	buildQualifiedWhere(builder)
+}
+
+// MergeClause merge UpdateFrom clauses
+func (from UpdateFrom) MergeClause(mergeClause *clause.Clause) {
+	if f, ok := mergeClause.Expression.(UpdateFrom); ok {
+		tables := make([]FromTable, len(f.Tables)+len(from.Tables))
+		copy(tables, f.Tables)
+		copy(tables[len(f.Tables):], from.Tables)
+		from.Tables = tables
+	}
+
+	mergeClause.Name = ""
+	mergeClause.Expression = from
+}
+
+// ModifyStatement add UpdateFrom clause to the statement.
+// MySQL joins tables right after the UPDATE target, so the clause is attached to the UPDATE clause there.
+// The joined tables may have the same columns as the target table, so the clause writes SET clause on MySQL and WHERE clause
+// with the columns qualified with the target table, such as the primary key of the model that gorm adds to the conditions.
+func (from UpdateFrom) ModifyStatement(stmt *gorm.Statement) {
+	omitted := []string{"WHERE"}
+	if statementDialect(stmt) == dialectMySQL {
+		c := stmt.Clauses["UPDATE"]
+		merged := clause.Clause{Expression: c.AfterExpression}
+		from.MergeClause(&merged)
+		c.AfterExpression = merged.Expression
+		stmt.Clauses["UPDATE"] = c
+		omitted = append(omitted, "SET")
+	} else {
+		addClause(stmt, from)
+	}
+	moveJoinConditions(stmt, from.Tables)
+	stmt.BuildClauses = withoutClauses(updateBuildClauses(stmt), omitted)
+}
+
+// NewUpdateFrom is easy to create new UpdateFrom
+//
+//	// examples
+//	// UPDATE `users` SET `name`=`profiles`.`name` FROM `profiles` WHERE `users`.`id` = `profiles`.`user_id`
+//	db.Clauses(exclause.NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).Table("users").Update("name", gorm.Expr("`profiles`.`name`"))
+func NewUpdateFrom(table interface{}, on ...clause.Expression) UpdateFrom {
+	return UpdateFrom{
+		Tables: []FromTable{{Table: table, On: on}},
+	}
+}
+
+// addClause merges the clause into the statement, as gorm.Statement.AddClause does for non modifier clauses
+func addClause(stmt *gorm.Statement, v clause.Interface) {
+	name := v.Name()
+	c := stmt.Clauses[name]
+	c.Name = name
+	v.MergeClause(&c)
+	stmt.Clauses[name] = c
+}
+
+// moveJoinConditions adds join conditions to WHERE clause, which also satisfies the WHERE conditions check of gorm
+func moveJoinConditions(stmt *gorm.Statement, tables []FromTable) {
+	for _, table := range tables {
+		if len(table.On) > 0 {
+			stmt.AddClause(clause.Where{Exprs: table.On})
+		}
+	}
+}
+
+// buildTableList writes tables separated by comma
+func buildTableList(builder clause.Builder, tables []FromTable) {
+	for index, table := range tables {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+		buildTable(builder, table.Table)
+	}
+}
+
+// buildCrossJoinedTables writes tables as CROSS JOIN, their join conditions are written in WHERE clause
+func buildCrossJoinedTables(builder clause.Builder, tables []FromTable) {
+	for index, table := range tables {
+		if index > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString("CROSS JOIN ")
+		buildTable(builder, table.Table)
+	}
+}
+
+// updateBuildClauses returns the clauses of the update statement
+func updateBuildClauses(stmt *gorm.Statement) []string {
+	if len(stmt.BuildClauses) > 0 {
+		return stmt.BuildClauses
+	}
+	return stmt.DB.Callback().Update().Clauses
+}
+
+// withoutClauses returns the clause names except the omitted names
+func withoutClauses(names []string, omitted []string) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if !slices.Contains(omitted, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// buildQualifiedSet writes SET clause of the statement with the columns qualified with the target table
+func buildQualifiedSet(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		return
+	}
+	set, ok := stmt.Clauses["SET"].Expression.(clause.Set)
+	if !ok {
+		return
+	}
+
+	qualified := make(clause.Set, len(set))
+	for index, assignment := range set {
+		if assignment.Column.Table == "" && !assignment.Column.Raw {
+			assignment.Column.Table = clause.CurrentTable
+		}
+		qualified[index] = assignment
+	}
+	builder.WriteString(" SET ")
+	qualified.Build(builder)
+}
+
+// buildQualifiedWhere writes WHERE clause of the statement with the columns of conditions qualified with the target table
+func buildQualifiedWhere(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		return
+	}
+	c, ok := stmt.Clauses["WHERE"]
+	if !ok || c.Expression == nil {
+		return
+	}
+	if where, ok := c.Expression.(clause.Where); ok {
+		exprs := make([]clause.Expression, len(where.Exprs))
+		for index, expr := range where.Exprs {
+			exprs[index] = qualifyCondition(expr)
+		}
+		c.Expression = clause.Where{Exprs: exprs}
+	}
+	builder.WriteByte(' ')
+	c.Build(builder)
+}
+
+// qualifyCondition qualifies the column of the conditions that gorm builds, such as the primary key of the model
+func qualifyCondition(expr clause.Expression) clause.Expression {
+	switch v := expr.(type) {
+	case clause.Eq:
+		v.Column = qualifyColumn(v.Column)
+		return v
+	case clause.Neq:
+		v.Column = qualifyColumn(v.Column)
+		return v
+	case clause.IN:
+		v.Column = qualifyColumn(v.Column)
+		return v
+	case clause.AndConditions:
+		exprs := make([]clause.Expression, len(v.Exprs))
+		for index, e := range v.Exprs {
+			exprs[index] = qualifyCondition(e)
+		}
+		return clause.AndConditions{Exprs: exprs}
+	}
+	return expr
+}
+
+// qualifyColumn qualifies the column name without table with the target table
+func qualifyColumn(column interface{}) interface{} {
+	switch v := column.(type) {
+	case string:
+		if !strings.Contains(v, ".") {
+			return clause.Column{Table: clause.CurrentTable, Name: v}
+		}
+	case clause.Column:
+		if v.Table == "" && !v.Raw {
+			v.Table = clause.CurrentTable
+			return v
+		}
+	}
+	return column
+}
+
+// buildTable writes table name or table expression
+func buildTable(builder clause.Builder, table interface{}) {
+	switch v := table.(type) {
+	case string:
+		builder.WriteQuoted(clause.Table{Name: v})
+	case clause.Table:
+		builder.WriteQuoted(v)
+	case clause.Expression:
+		v.Build(builder)
+	default:
+		builder.AddError(gorm.ErrInvalidValue)
+	}
+}
@@ -0,0 +1,197 @@
+package exclause
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type updateUser struct {
+	ID   uint
+	Name string
+}
+
+func TestUpdateFrom_Update(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name:    "When dialect is postgres, then should be used FROM and join condition in WHERE",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Where("`profiles`.`active` = ?", true).Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `users` SET `name`=`profiles`.`name` FROM `profiles` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`active` = ?",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When dialect is sqlite, then should be used FROM with comma separated tables",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(UpdateFrom{Tables: []FromTable{
+					{Table: "profiles", On: []clause.Expression{clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"}}},
+					{Table: clause.Table{Name: "teams", Alias: "t"}, On: []clause.Expression{clause.Expr{SQL: "`users`.`team_id` = `t`.`id`"}}},
+				}}).Table("users").Update("name", gorm.Expr("`t`.`name`"))
+			},
+			want:     "UPDATE `users` SET `name`=`t`.`name` FROM `profiles`,`teams` `t` WHERE `users`.`id` = `profiles`.`user_id` AND `users`.`team_id` = `t`.`id`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is mysql, then should be used CROSS JOIN before SET and qualified SET columns",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Where("`profiles`.`active` = ?", true).Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `users` CROSS JOIN `profiles` SET `users`.`name`=`profiles`.`name` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`active` = ?",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When dialect is mysql and has only join condition, then should be used join condition as WHERE",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Updates(map[string]interface{}{"name": gorm.Expr("`profiles`.`name`"), "age": 20})
+			},
+			want:     "UPDATE `users` CROSS JOIN `profiles` SET `users`.`age`=?,`users`.`name`=`profiles`.`name` WHERE `users`.`id` = `profiles`.`user_id`",
+			wantArgs: []driver.Value{20},
+		},
+		{
+			name:    "When dialect is mysql and has multiple UpdateFrom, then should be used all JOIN",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Clauses(NewUpdateFrom("settings")).
+					Table("users").Where("`profiles`.`active` = ?", true).Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `users` CROSS JOIN `profiles` CROSS JOIN `settings` SET `users`.`name`=`profiles`.`name` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`active` = ?",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When dialect is sqlserver, then should be used FROM with target table and CROSS JOIN",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Where("`profiles`.`active` = ?", true).Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `users` SET `name`=`profiles`.`name` FROM `users` CROSS JOIN `profiles` WHERE `users`.`id` = `profiles`.`user_id` AND `profiles`.`active` = ?",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When dialect is sqlserver and has only join condition, then should be used join condition as WHERE",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"})).
+					Table("users").Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `users` SET `name`=`profiles`.`name` FROM `users` CROSS JOIN `profiles` WHERE `users`.`id` = `profiles`.`user_id`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is postgres and has model, then should be qualified primary key with the target table",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`update_users`.`id` = `profiles`.`user_id`"})).
+					Model(&updateUser{ID: 1}).Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `update_users` SET `name`=`profiles`.`name` FROM `profiles` WHERE `update_users`.`id` = `profiles`.`user_id` AND `update_users`.`id` = ?",
+			wantArgs: []driver.Value{1},
+		},
+		{
+			name:    "When dialect is mysql and has model, then should be qualified SET columns and primary key with the target table",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`update_users`.`id` = `profiles`.`user_id`"})).
+					Model(&updateUser{ID: 1}).Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `update_users` CROSS JOIN `profiles` SET `update_users`.`name`=`profiles`.`name` WHERE `update_users`.`id` = `profiles`.`user_id` AND `update_users`.`id` = ?",
+			wantArgs: []driver.Value{1},
+		},
+		{
+			name:    "When dialect is sqlserver and has model, then should be qualified primary key with the target table",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom("profiles", clause.Expr{SQL: "`update_users`.`id` = `profiles`.`user_id`"})).
+					Model(&updateUser{ID: 1}).Update("name", gorm.Expr("`profiles`.`name`"))
+			},
+			want:     "UPDATE `update_users` SET `name`=`profiles`.`name` FROM `update_users` CROSS JOIN `profiles` WHERE `update_users`.`id` = `profiles`.`user_id` AND `update_users`.`id` = ?",
+			wantArgs: []driver.Value{1},
+		},
+		{
+			name:    "When table is derived table, then should be used as table",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewUpdateFrom(
+					clause.Expr{SQL: "(?) AS `p`", Vars: []interface{}{db.Table("profiles").Where("`active` = ?", true)}},
+					clause.Expr{SQL: "`users`.`id` = `p`.`user_id`"},
+				)).Table("users").Update("name", gorm.Expr("`p`.`name`"))
+			},
+			want:     "UPDATE `users` SET `name`=`p`.`name` FROM (SELECT * FROM `profiles` WHERE `active` = ?) AS `p` WHERE `users`.`id` = `p`.`user_id`",
+			wantArgs: []driver.Value{true},
+		},
+		{
+			name:    "When table is CTE, then should be used with WITH clause",
+			dialect: dialectPostgres,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("profiles").Where("`active` = ?", true))).
+					Clauses(NewUpdateFrom("cte", clause.Expr{SQL: "`users`.`id` = `cte`.`user_id`"})).
+					Table("users").Update("name", gorm.Expr("`cte`.`name`"))
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `profiles` WHERE `active` = ?) UPDATE `users` SET `name`=`cte`.`name` FROM `cte` WHERE `users`.`id` = `cte`.`user_id`",
+			wantArgs: []driver.Value{true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			if tt.operation != nil {
+				db = tt.operation(db)
+			}
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+		})
+	}
+}
+
+func TestNewUpdateFrom(t *testing.T) {
+	on := clause.Expr{SQL: "`users`.`id` = `profiles`.`user_id`"}
+	tests := []struct {
+		name  string
+		table interface{}
+		on    []clause.Expression
+		want  UpdateFrom
+	}{
+		{
+			name:  "When has condition, then table has join condition",
+			table: "profiles",
+			on:    []clause.Expression{on},
+			want:  UpdateFrom{Tables: []FromTable{{Table: "profiles", On: []clause.Expression{on}}}},
+		},
+		{
+			name:  "When has no condition, then table has no join condition",
+			table: clause.Table{Name: "profiles"},
+			want:  UpdateFrom{Tables: []FromTable{{Table: clause.Table{Name: "profiles"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewUpdateFrom(tt.table, tt.on...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewUpdateFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package exclause
 
 import (
+	"fmt"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-// CTEMaterializeOption represents the materialization hint for a CTE
+// CTEMaterializeOption represents the materialization hint for a CTE.
+// It is rendered as MATERIALIZED keywords on PostgreSQL and SQLite, /*+ MATERIALIZE */ hint in the subquery on Oracle
+// and /*+ NO_MERGE(cte) */ hint of the statement on MySQL. SQL Server has no equivalent.
+// The hint of Oracle needs *gorm.DB subquery, as raw SQL can't be hinted.
 type CTEMaterializeOption int
 
 const (
@@ -32,7 +37,9 @@ const (
 //	// WITH RECURSIVE `cte` AS (SELECT * FROM `users`) SELECT * FROM `cte`
 //	db.Clauses(exclause.With{Recursive: true, CTEs: []exclause.CTE{{Name: "cte", Subquery: exclause.Subquery{DB: db.Table("users")}}}}).Table("cte").Scan(&users)
 //
-//	// WITH `cte` AS MATERIALIZED (SELECT * FROM `users`) SELECT * FROM `cte`
+//	// PostgreSQL: WITH `cte` AS MATERIALIZED (SELECT * FROM `users`) SELECT * FROM `cte`
+//	// Oracle:     WITH `cte` AS (SELECT /*+ MATERIALIZE */ * FROM `users`) SELECT * FROM `cte`
+//	// MySQL:      WITH `cte` AS (SELECT * FROM `users`) SELECT /*+ NO_MERGE(`cte`) */ * FROM `cte`
 //	db.Clauses(exclause.With{CTEs: []exclause.CTE{{Name: "cte", Subquery: exclause.Subquery{DB: db.Table("users")}, Materialized: exclause.CTEMaterialize}}}).Table("cte").Scan(&users)
 //
 //	// WITH `cte` AS NOT MATERIALIZED (SELECT * FROM `users`) SELECT * FROM `cte`
@@ -44,6 +51,7 @@ const (
 //		exclause.NewNotMaterializedCTE("cte2", exclause.Subquery{DB: db.Table("products")}),
 //	}}).Table("cte1").Scan(&users)
 type With struct {
+	// Recursive writes RECURSIVE keyword, except on SQL Server and Oracle whose CTEs can be recursive without it
 	Recursive bool
 	CTEs      []CTE
 }
@@ -105,7 +113,7 @@ func (with With) Name() string {
 
 // Build build with clause
 func (with With) Build(builder clause.Builder) {
-	if with.Recursive {
+	if dialect := dialectOf(builder); with.Recursive && dialect != dialectSQLServer && dialect != dialectOracle {
 		builder.WriteString("RECURSIVE ")
 	}
 	for index, cte := range with.CTEs {
@@ -132,18 +140,64 @@ func (cte CTE) Build(builder clause.Builder) {
 
 	builder.WriteString(" AS ")
 
-	switch cte.Materialized {
-	case CTEMaterialize:
-		builder.WriteString("MATERIALIZED ")
-	case CTENotMaterialize:
-		builder.WriteString("NOT MATERIALIZED ")
+	subquery := cte.Subquery
+	switch dialect := dialectOf(builder); dialect {
+	case dialectMySQL:
+		// hinted to the statement by With.ModifyStatement
+	case dialectOracle:
+		if hint, ok := oracleMaterializeHints[cte.Materialized]; ok {
+			if subquery, ok = hintSubquery(subquery, hint); !ok {
+				builder.AddError(fmt.Errorf("%w: materialization hint of CTE %s needs *gorm.DB subquery on %s", ErrUnsupportedDialect, cte.Name, dialect))
+				return
+			}
+		}
+	case dialectSQLServer:
+		if cte.Materialized != CTEMaterializeUnspecified {
+			builder.AddError(fmt.Errorf("%w: CTE materialization on %s", ErrUnsupportedDialect, dialect))
+		}
+	default:
+		switch cte.Materialized {
+		case CTEMaterialize:
+			builder.WriteString("MATERIALIZED ")
+		case CTENotMaterialize:
+			builder.WriteString("NOT MATERIALIZED ")
+		}
 	}
 
 	builder.WriteByte('(')
-	cte.Subquery.Build(builder)
+	subquery.Build(builder)
 	builder.WriteByte(')')
 }
 
+var oracleMaterializeHints = map[CTEMaterializeOption]string{
+	CTEMaterialize:    "MATERIALIZE",
+	CTENotMaterialize: "INLINE",
+}
+
+// hintSubquery returns the subquery with the optimizer hint after its SELECT keyword, e.g. SELECT /*+ MATERIALIZE */ * FROM `users`.
+// Set operations are hinted in the first query. Raw SQL can't be hinted, so false is returned for it.
+func hintSubquery(subquery clause.Expression, hint string) (clause.Expression, bool) {
+	switch v := subquery.(type) {
+	case Subquery:
+		if v.DB == nil {
+			return subquery, false
+		}
+		return Subquery{DB: v.DB.Session(&gorm.Session{}).Clauses(NewHint(hint))}, true
+	case SetOperation:
+		if len(v.Statements) == 0 {
+			return subquery, false
+		}
+		first, ok := hintSubquery(v.Statements[0], hint)
+		if !ok {
+			return subquery, false
+		}
+		v.Statements = append([]clause.Expression{first}, v.Statements[1:]...)
+		return v, true
+	default:
+		return subquery, false
+	}
+}
+
 // MergeClause merge With clauses
 func (with With) MergeClause(clause *clause.Clause) {
 	if w, ok := clause.Expression.(With); ok {
@@ -159,6 +213,29 @@ func (with With) MergeClause(clause *clause.Clause) {
 	clause.Expression = with
 }
 
+// ModifyStatement add With clause to the statement.
+// MySQL has no materialization keyword, so the materialization of CTEs is hinted to the statement there.
+func (with With) ModifyStatement(stmt *gorm.Statement) {
+	addClause(stmt, with)
+	moveWithToInsertSelect(stmt)
+	if statementDialect(stmt) != dialectMySQL {
+		return
+	}
+
+	hints := []clause.Expression{}
+	for _, cte := range with.CTEs {
+		switch cte.Materialized {
+		case CTEMaterialize:
+			hints = append(hints, clause.Expr{SQL: "NO_MERGE(?)", Vars: []interface{}{clause.Table{Name: cte.Name}}})
+		case CTENotMaterialize:
+			hints = append(hints, clause.Expr{SQL: "MERGE(?)", Vars: []interface{}{clause.Table{Name: cte.Name}}})
+		}
+	}
+	if len(hints) > 0 {
+		addOptimizerHints(stmt, hints...)
+	}
+}
+
 // NewWith is easy to create new With
 //
 //	// examples
@@ -171,6 +248,9 @@ func (with With) MergeClause(clause *clause.Clause) {
 //	// WITH `cte` AS (SELECT * FROM `users` WHERE `name` = 'WinterYukky') SELECT * FROM `cte`
 //	db.Clauses(exclause.NewWith("cte", db.Table("users").Where("`name` = ?", "WinterYukky"))).Table("cte").Scan(&users)
 //
+//	// WITH `cte` AS (SELECT * FROM `admin_users` UNION SELECT * FROM `guest_users`) SELECT * FROM `cte`
+//	db.Clauses(exclause.NewWith("cte", exclause.UnionOf(db.Table("admin_users"), db.Table("guest_users")))).Table("cte").Scan(&users)
+//
 // If you need more advanced WITH clause, you can see With struct.
 func NewWith(name string, subquery interface{}, args ...interface{}) With {
 	switch v := subquery.(type) {
@@ -192,6 +272,15 @@ func NewWith(name string, subquery interface{}, args ...interface{}) With {
 				},
 			},
 		}
+	case clause.Expression:
+		return With{
+			CTEs: []CTE{
+				{
+					Name:     name,
+					Subquery: v,
+				},
+			},
+		}
 	}
 	return With{}
 }
@@ -2,6 +2,7 @@ package exclause
 
 import (
 	"database/sql/driver"
+	"errors"
 	"reflect"
 	"regexp"
 	"testing"
@@ -16,6 +17,7 @@ import (
 func TestWith_Query(t *testing.T) {
 	tests := []struct {
 		name      string
+		dialect   string
 		operation func(db *gorm.DB) *gorm.DB
 		want      string
 		wantArgs  []driver.Value
@@ -56,7 +58,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{},
 		},
 		{
-			name: "When Materialized is CTEMaterialize, then should use MATERIALIZED keyword",
+			name:    "When Materialized is CTEMaterialize, then should use MATERIALIZED keyword",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: Subquery{DB: db.Table("users")}, Materialized: CTEMaterialize}}}).Table("cte").Scan(nil)
 			},
@@ -64,13 +67,41 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{},
 		},
 		{
-			name: "When Materialized is CTENotMaterialize, then should use NOT MATERIALIZED keyword",
+			name:    "When Materialized is CTENotMaterialize, then should use NOT MATERIALIZED keyword",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: Subquery{DB: db.Table("users")}, Materialized: CTENotMaterialize}}}).Table("cte").Scan(nil)
 			},
 			want:     "WITH `cte` AS NOT MATERIALIZED (SELECT * FROM `users`) SELECT * FROM `cte`",
 			wantArgs: []driver.Value{},
 		},
+		{
+			name:    "When Materialized is CTEMaterialize on oracle, then should use MATERIALIZE hint in the subquery",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: Subquery{DB: db.Table("users")}, Materialized: CTEMaterialize}}}).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT /*+ MATERIALIZE */ * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When Materialized is CTENotMaterialize on oracle, then should use INLINE hint in the subquery",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: Subquery{DB: db.Table("users")}, Materialized: CTENotMaterialize}}}).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT /*+ INLINE */ * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When Materialized subquery has comment on oracle, then should use hint after SELECT",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: Subquery{DB: db.Clauses(NewComment("admins")).Table("users")}, Materialized: CTEMaterialize}}}).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (/* admins */ SELECT /*+ MATERIALIZE */ * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
 		{
 			name: "When Materialized is CTEMaterializeUnspecified, then should not use any materialization keyword",
 			operation: func(db *gorm.DB) *gorm.DB {
@@ -80,7 +111,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{},
 		},
 		{
-			name: "When multiple CTEs with different materialization options",
+			name:    "When multiple CTEs with different materialization options",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{
 					CTEs: []CTE{
@@ -94,7 +126,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{},
 		},
 		{
-			name: "When using NewMaterializedCTE helper",
+			name:    "When using NewMaterializedCTE helper",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", Subquery{DB: db.Table("users")})}}).Table("cte").Scan(nil)
 			},
@@ -102,7 +135,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{},
 		},
 		{
-			name: "When using NewNotMaterializedCTE helper",
+			name:    "When using NewNotMaterializedCTE helper",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{NewNotMaterializedCTE("cte", Subquery{DB: db.Table("users")})}}).Table("cte").Scan(nil)
 			},
@@ -118,7 +152,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{},
 		},
 		{
-			name: "When RECURSIVE with MATERIALIZED",
+			name:    "When RECURSIVE with MATERIALIZED",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{
 					Recursive: true,
@@ -129,7 +164,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{},
 		},
 		{
-			name: "When RECURSIVE with NOT MATERIALIZED",
+			name:    "When RECURSIVE with NOT MATERIALIZED",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{
 					Recursive: true,
@@ -140,7 +176,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{},
 		},
 		{
-			name: "When materialized with clause.Expr subquery",
+			name:    "When materialized with clause.Expr subquery",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: clause.Expr{SQL: "SELECT * FROM `users` WHERE `name` = ?", Vars: []interface{}{"WinterYukky"}}, Materialized: CTEMaterialize}}}).Table("cte").Scan(nil)
 			},
@@ -148,7 +185,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{"WinterYukky"},
 		},
 		{
-			name: "When materialized with columns specified",
+			name:    "When materialized with columns specified",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Columns: []string{"id", "name"}, Subquery: Subquery{DB: db.Table("users")}, Materialized: CTEMaterialize}}}).Table("cte").Scan(nil)
 			},
@@ -164,7 +202,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{"WinterYukky"},
 		},
 		{
-			name: "When using NewMaterializedCTE with string subquery and args",
+			name:    "When using NewMaterializedCTE with string subquery and args",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", "SELECT * FROM `users` WHERE `name` = ?", "WinterYukky")}}).Table("cte").Scan(nil)
 			},
@@ -172,7 +211,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{"WinterYukky"},
 		},
 		{
-			name: "When using NewNotMaterializedCTE with string subquery and args",
+			name:    "When using NewNotMaterializedCTE with string subquery and args",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{NewNotMaterializedCTE("cte", "SELECT * FROM `users` WHERE `name` = ?", "WinterYukky")}}).Table("cte").Scan(nil)
 			},
@@ -188,7 +228,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{"WinterYukky"},
 		},
 		{
-			name: "When using NewMaterializedCTE with *gorm.DB subquery",
+			name:    "When using NewMaterializedCTE with *gorm.DB subquery",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", db.Table("users").Where("`name` = ?", "WinterYukky"))}}).Table("cte").Scan(nil)
 			},
@@ -196,7 +237,8 @@ func TestWith_Query(t *testing.T) {
 			wantArgs: []driver.Value{"WinterYukky"},
 		},
 		{
-			name: "When using NewNotMaterializedCTE with *gorm.DB subquery",
+			name:    "When using NewNotMaterializedCTE with *gorm.DB subquery",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{NewNotMaterializedCTE("cte", db.Table("users").Where("`name` = ?", "WinterYukky"))}}).Table("cte").Scan(nil)
 			},
@@ -206,16 +248,23 @@ func TestWith_Query(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockDB, mock, err := sqlmock.New()
-			if err != nil {
-				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			var db *gorm.DB
+			var mock sqlmock.Sqlmock
+			if tt.dialect == "" {
+				mockDB, m, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+				}
+				defer mockDB.Close()
+				db, _ = gorm.Open(mysql.New(mysql.Config{
+					Conn:                      mockDB,
+					SkipInitializeWithVersion: true,
+				}))
+				db.Use(extraClausePlugin.New())
+				mock = m
+			} else {
+				db, mock = openDialectDB(t, tt.dialect)
 			}
-			defer mockDB.Close()
-			db, _ := gorm.Open(mysql.New(mysql.Config{
-				Conn:                      mockDB,
-				SkipInitializeWithVersion: true,
-			}))
-			db.Use(extraClausePlugin.New())
 			mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
 			if tt.operation != nil {
 				db = tt.operation(db)
@@ -230,6 +279,7 @@ func TestWith_Query(t *testing.T) {
 func TestWith_Update(t *testing.T) {
 	tests := []struct {
 		name      string
+		dialect   string
 		operation func(db *gorm.DB) *gorm.DB
 		want      string
 		wantArgs  []driver.Value
@@ -270,7 +320,8 @@ func TestWith_Update(t *testing.T) {
 			wantArgs: []driver.Value{"new_name"},
 		},
 		{
-			name: "When Materialized is CTEMaterialize in update",
+			name:    "When Materialized is CTEMaterialize in update",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: Subquery{DB: db.Table("users").Where("`name` = ?", "WinterYukky")}, Materialized: CTEMaterialize}}}).Table("users").Where("`users`.`id` IN (SELECT `id` FROM `cte`)").Update("name", "new_name")
 			},
@@ -278,7 +329,8 @@ func TestWith_Update(t *testing.T) {
 			wantArgs: []driver.Value{"WinterYukky", "new_name"},
 		},
 		{
-			name: "When Materialized is CTENotMaterialize in update",
+			name:    "When Materialized is CTENotMaterialize in update",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: Subquery{DB: db.Table("users").Where("`name` = ?", "WinterYukky")}, Materialized: CTENotMaterialize}}}).Table("users").Where("`users`.`id` IN (SELECT `id` FROM `cte`)").Update("name", "new_name")
 			},
@@ -286,7 +338,17 @@ func TestWith_Update(t *testing.T) {
 			wantArgs: []driver.Value{"WinterYukky", "new_name"},
 		},
 		{
-			name: "When using NewMaterializedCTE with string subquery in update",
+			name:    "When Materialized is CTEMaterialize in update on oracle",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{{Name: "cte", Subquery: Subquery{DB: db.Table("users").Where("`name` = ?", "WinterYukky")}, Materialized: CTEMaterialize}}}).Table("users").Where("`users`.`id` IN (SELECT `id` FROM `cte`)").Update("name", "new_name")
+			},
+			want:     "WITH `cte` AS (SELECT /*+ MATERIALIZE */ * FROM `users` WHERE `name` = ?) UPDATE `users` SET `name`=? WHERE `users`.`id` IN (SELECT `id` FROM `cte`)",
+			wantArgs: []driver.Value{"WinterYukky", "new_name"},
+		},
+		{
+			name:    "When using NewMaterializedCTE with string subquery in update",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", "SELECT * FROM `users` WHERE `name` = ?", "WinterYukky")}}).Table("users").Where("`users`.`id` IN (SELECT `id` FROM `cte`)").Update("name", "new_name")
 			},
@@ -294,7 +356,8 @@ func TestWith_Update(t *testing.T) {
 			wantArgs: []driver.Value{"WinterYukky", "new_name"},
 		},
 		{
-			name: "When using NewNotMaterializedCTE with *gorm.DB in update",
+			name:    "When using NewNotMaterializedCTE with *gorm.DB in update",
+			dialect: dialectPostgres,
 			operation: func(db *gorm.DB) *gorm.DB {
 				return db.Clauses(With{CTEs: []CTE{NewNotMaterializedCTE("cte", db.Table("users").Where("`name` = ?", "WinterYukky"))}}).Table("users").Where("`users`.`id` IN (SELECT `id` FROM `cte`)").Update("name", "new_name")
 			},
@@ -304,16 +367,23 @@ func TestWith_Update(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockDB, mock, err := sqlmock.New()
-			if err != nil {
-				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			var db *gorm.DB
+			var mock sqlmock.Sqlmock
+			if tt.dialect == "" {
+				mockDB, m, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+				}
+				defer mockDB.Close()
+				db, _ = gorm.Open(mysql.New(mysql.Config{
+					Conn:                      mockDB,
+					SkipInitializeWithVersion: true,
+				}))
+				db.Use(extraClausePlugin.New())
+				mock = m
+			} else {
+				db, mock = openDialectDB(t, tt.dialect)
 			}
-			defer mockDB.Close()
-			db, _ := gorm.Open(mysql.New(mysql.Config{
-				Conn:                      mockDB,
-				SkipInitializeWithVersion: true,
-			}))
-			db.Use(extraClausePlugin.New())
 			mock.ExpectBegin()
 			mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
 			mock.ExpectCommit()
@@ -327,6 +397,161 @@ func TestWith_Update(t *testing.T) {
 	}
 }
 
+func TestWith_Dialect(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		exec      bool
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+		wantErr   error
+	}{
+		{
+			name:    "When dialect is sqlite, then should use MATERIALIZED keyword",
+			dialect: dialectSQLite,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", db.Table("users"))}}).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS MATERIALIZED (SELECT * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is oracle, then should use hints in the subquery",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{
+					NewMaterializedCTE("cte1", db.Table("users").Where("`name` = ?", "WinterYukky")),
+					NewNotMaterializedCTE("cte2", db.Table("products").Where("`price` > ?", 100)),
+					NewCTE("cte3", db.Table("orders")),
+				}}).Table("cte1").Scan(nil)
+			},
+			want:     "WITH `cte1` AS (SELECT /*+ MATERIALIZE */ * FROM `users` WHERE `name` = ?),`cte2` AS (SELECT /*+ INLINE */ * FROM `products` WHERE `price` > ?),`cte3` AS (SELECT * FROM `orders`) SELECT * FROM `cte1`",
+			wantArgs: []driver.Value{"WinterYukky", 100},
+		},
+		{
+			name:    "When dialect is oracle and subquery is set operation, then should use hint in the first query",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", UnionOf(db.Table("admin_users"), db.Table("guest_users")))}}).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT /*+ MATERIALIZE */ * FROM `admin_users` UNION SELECT * FROM `guest_users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is sqlserver and recursive, then should not use RECURSIVE keyword",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{Recursive: true, CTEs: []CTE{NewCTE("cte", db.Table("users"))}}).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is oracle and subquery is raw SQL, then should be error",
+			dialect: dialectOracle,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", "VALUES (1)")}}).Table("cte").Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When dialect is mysql, then should use MERGE and NO_MERGE hints in the statement",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{
+					NewMaterializedCTE("cte1", db.Table("users")),
+					NewNotMaterializedCTE("cte2", db.Table("products")),
+				}}).Table("cte1").Select("`id`").Scan(nil)
+			},
+			want:     "WITH `cte1` AS (SELECT * FROM `users`),`cte2` AS (SELECT * FROM `products`) SELECT /*+ NO_MERGE(`cte1`) MERGE(`cte2`) */ `id` FROM `cte1`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is mysql and same CTE is added twice, then should not duplicate hints",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				with := With{CTEs: []CTE{NewMaterializedCTE("cte", db.Table("users"))}}
+				return db.Clauses(with).Clauses(with).Table("cte").Scan(nil)
+			},
+			want:     "SELECT /*+ NO_MERGE(`cte`) */ * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is mysql and statement is count, then should use hint in the count",
+			dialect: dialectMySQL,
+			operation: func(db *gorm.DB) *gorm.DB {
+				var count int64
+				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", db.Table("users"))}}).Table("cte").Count(&count)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users`) SELECT /*+ NO_MERGE(`cte`) */ count(*) FROM (SELECT * FROM `cte`) AS `t`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is mysql and statement is update, then should use hint after UPDATE",
+			dialect: dialectMySQL,
+			exec:    true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", db.Table("users").Where("`name` = ?", "WinterYukky"))}}).
+					Table("users").Where("`users`.`id` IN (SELECT `id` FROM `cte`)").Update("name", "new_name")
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users` WHERE `name` = ?) UPDATE /*+ NO_MERGE(`cte`) */ `users` SET `name`=? WHERE `users`.`id` IN (SELECT `id` FROM `cte`)",
+			wantArgs: []driver.Value{"WinterYukky", "new_name"},
+		},
+		{
+			name:    "When dialect is mysql and statement is delete, then should use hint after DELETE",
+			dialect: dialectMySQL,
+			exec:    true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{NewNotMaterializedCTE("cte", db.Table("users"))}}).
+					Table("users").Where("`users`.`id` IN (SELECT `id` FROM `cte`)").Delete(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users`) DELETE /*+ MERGE(`cte`) */ FROM `users` WHERE `users`.`id` IN (SELECT `id` FROM `cte`)",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name:    "When dialect is sqlserver, then should be error",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(With{CTEs: []CTE{NewMaterializedCTE("cte", db.Table("users"))}}).Table("cte").Scan(nil)
+			},
+			wantErr: ErrUnsupportedDialect,
+		},
+		{
+			name:    "When dialect is sqlserver and materialization is unspecified, then should not be error",
+			dialect: dialectSQLServer,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(NewWith("cte", db.Table("users"))).Table("cte").Scan(nil)
+			},
+			want:     "WITH `cte` AS (SELECT * FROM `users`) SELECT * FROM `cte`",
+			wantArgs: []driver.Value{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openDialectDB(t, tt.dialect)
+			if tt.exec {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(tt.want)).WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+			db = tt.operation(db)
+			if tt.wantErr != nil {
+				if !errors.Is(db.Error, tt.wantErr) {
+					t.Errorf("error is %v, want %v", db.Error, tt.wantErr)
+				}
+			} else if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func TestNewWith(t *testing.T) {
 	mockDB, _, err := sqlmock.New()
 	if err != nil {
@@ -384,6 +609,22 @@ func TestNewWith(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "When subquery is clause.Expression, then CTE's Subquery is the expression",
+			args: args{
+				name:     "cte",
+				subquery: UnionOf(db, db),
+			},
+			want: With{
+				Recursive: false,
+				CTEs: []CTE{
+					{
+						Name:     "cte",
+						Subquery: UnionOf(db, db),
+					},
+				},
+			},
+		},
 		{
 			name: "When subquery is else, then CTE's Subquery is empty With",
 			args: args{
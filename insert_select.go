@@ -0,0 +1,21 @@
+package gormextraclauseplugin
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// insertSelectClause is the clause name of INSERT ... SELECT source, that is exclause.InsertSelect
+const insertSelectClause = "INSERT SELECT"
+
+// buildInsertSelect builds the create statement with INSERT ... SELECT source instead of VALUES of the created model,
+// so that gorm:create executes the statement without converting the model to VALUES.
+func buildInsertSelect(db *gorm.DB) {
+	c, ok := db.Statement.Clauses[insertSelectClause]
+	if !ok || db.Error != nil || db.Statement.SQL.Len() > 0 {
+		return
+	}
+	db.Statement.AddClauseIfNotExists(clause.Insert{})
+	db.Statement.Clauses["VALUES"] = clause.Clause{Expression: c.Expression}
+	db.Statement.Build(db.Statement.BuildClauses...)
+}
@@ -0,0 +1,54 @@
+package gormextraclauseplugin
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+)
+
+// outputClause is the clause name of SQL Server OUTPUT clause, that is exclause.Output
+const outputClause = "OUTPUT"
+
+// callbackProcessor is the processor of gorm callbacks, such as db.Callback().Create()
+type callbackProcessor interface {
+	Get(name string) func(*gorm.DB)
+	Replace(name string, fn func(*gorm.DB)) error
+}
+
+// registerOutput replaces gorm:create, gorm:update and gorm:delete callbacks,
+// so that the statements with OUTPUT clause are executed as query and the results are scanned back into the model like RETURNING.
+func (e *ExtraClausePlugin) registerOutput(db *gorm.DB) error {
+	returning := &callbacks.Config{
+		CreateClauses: []string{"RETURNING"},
+		UpdateClauses: []string{"RETURNING"},
+		DeleteClauses: []string{"RETURNING"},
+	}
+	for _, c := range []struct {
+		processor callbackProcessor
+		name      string
+		returning func(*gorm.DB)
+	}{
+		{processor: db.Callback().Create(), name: "gorm:create", returning: callbacks.Create(returning)},
+		{processor: db.Callback().Update(), name: "gorm:update", returning: callbacks.Update(returning)},
+		{processor: db.Callback().Delete(), name: "gorm:delete", returning: callbacks.Delete(returning)},
+	} {
+		original := c.processor.Get(c.name)
+		if original == nil {
+			continue
+		}
+		if err := c.processor.Replace(c.name, withOutput(original, c.returning)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withOutput calls returning callback for the statements with OUTPUT clause, and original callback for others
+func withOutput(original, returning func(*gorm.DB)) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if _, ok := db.Statement.Clauses[outputClause]; ok {
+			returning(db)
+			return
+		}
+		original(db)
+	}
+}
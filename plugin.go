@@ -1,13 +1,39 @@
 package gormextraclauseplugin
 
 import (
+	"fmt"
 	"slices"
 
 	"gorm.io/gorm"
 )
 
+// ErrUnsupportedSetOperation is returned when UNION, INTERSECT or EXCEPT clause is added to UPDATE statement.
+// Use exclause.SetOperation as subquery instead, e.g. WHERE `id` IN (SELECT ... UNION SELECT ...).
+var ErrUnsupportedSetOperation = fmt.Errorf("%w: set operation clause is not supported in UPDATE statement", gorm.ErrInvalidData)
+
+// ErrUnsupportedSoftDelete is returned when DELETE USING clause is added to the delete statement of soft deleted model.
+// gorm turns the statement into UPDATE, which drops the joined tables, so use Unscoped or exclause.UpdateFrom instead.
+var ErrUnsupportedSoftDelete = fmt.Errorf("%w: DELETE USING clause is not supported with soft delete", gorm.ErrInvalidData)
+
 // ExtraClausePlugin support plugin that not supported clause by gorm
-type ExtraClausePlugin struct{}
+type ExtraClausePlugin struct {
+	validateSetOperations bool
+	sqlCommenterKeys      map[string]interface{}
+}
+
+// Option is an option of ExtraClausePlugin
+type Option func(*ExtraClausePlugin)
+
+// WithSetOperationValidation validates that UNION, INTERSECT and EXCEPT branches select compatible columns before the query is executed.
+// Branches whose columns are unknown, such as raw SQL or SELECT * without model, are not validated.
+//
+//	// example
+//	db.Use(extraClausePlugin.New(extraClausePlugin.WithSetOperationValidation()))
+func WithSetOperationValidation() Option {
+	return func(e *ExtraClausePlugin) {
+		e.validateSetOperations = true
+	}
+}
 
 // Name return plugin name
 func (e *ExtraClausePlugin) Name() string {
@@ -17,45 +43,132 @@ func (e *ExtraClausePlugin) Name() string {
 // Initialize register BuildClauses
 func (e *ExtraClausePlugin) Initialize(db *gorm.DB) error {
 
+	db.Callback().Create().Clauses = merge(db.Callback().Create().Clauses, createClauses)
 	db.Callback().Query().Clauses = merge(db.Callback().Query().Clauses, queryClauses)
 	db.Callback().Row().Clauses = merge(db.Callback().Row().Clauses, queryClauses)
 	db.Callback().Update().Clauses = merge(db.Callback().Update().Clauses, updateClauses)
-	return nil
+	db.Callback().Delete().Clauses = merge(db.Callback().Delete().Clauses, deleteClauses)
+	if e.validateSetOperations {
+		if err := db.Callback().Query().Before("gorm:query").Register("extra_clause:validate_set_operations", validateSetOperations); err != nil {
+			return err
+		}
+		if err := db.Callback().Row().Before("gorm:row").Register("extra_clause:validate_set_operations", validateSetOperations); err != nil {
+			return err
+		}
+	}
+	if e.sqlCommenterKeys != nil {
+		if err := e.registerSQLCommenter(db); err != nil {
+			return err
+		}
+	}
+	if err := e.registerOutput(db); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().Before("gorm:create").Register("extra_clause:insert_select", buildInsertSelect); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("extra_clause:wrap_count", wrapCount); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:begin_transaction").Register("extra_clause:reject_soft_delete_using", rejectSoftDeleteUsing); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:begin_transaction").Register("extra_clause:reject_set_operations", rejectSetOperations)
 }
 
 // New create new ExtraClausePlugin
 //
 //	// example
 //	db.Use(extraClausePlugin.New())
-func New() *ExtraClausePlugin {
-	return &ExtraClausePlugin{}
+func New(opts ...Option) *ExtraClausePlugin {
+	e := &ExtraClausePlugin{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 type pluginClause struct {
-	name   string
+	name string
+	// before is the clause name that the clause is inserted before, or empty to append to the end
 	before string
 }
 
 var (
+	createClauses = []pluginClause{
+		{name: "COMMENT", before: "INSERT"},
+		{name: "WITH", before: "INSERT"},
+	}
 	queryClauses = []pluginClause{
+		{name: "COMMENT", before: "SELECT"},
 		{name: "WITH", before: "SELECT"},
 		{name: "UNION", before: "ORDER BY"},
 		{name: "INTERSECT", before: "ORDER BY"},
 		{name: "EXCEPT", before: "ORDER BY"},
+		{name: "CONNECT BY", before: "GROUP BY"},
+		{name: "FETCH", before: "LIMIT"},
 	}
 	updateClauses = []pluginClause{
+		{name: "COMMENT", before: "UPDATE"},
 		{name: "WITH", before: "UPDATE"},
-		{name: "UNION", before: "ORDER BY"},
-		{name: "INTERSECT", before: "ORDER BY"},
-		{name: "EXCEPT", before: "ORDER BY"},
+		{name: "OUTPUT", before: "WHERE"},
+		{name: "UPDATE FROM", before: "WHERE"},
+	}
+	deleteClauses = []pluginClause{
+		{name: "COMMENT", before: "DELETE"},
+		{name: "WITH", before: "DELETE"},
+		{name: "OUTPUT", before: "WHERE"},
+		{name: "DELETE USING", before: "WHERE"},
 	}
 )
 
+// setOperationClauses are clause names of set operations, which are valid only in query statements
+var setOperationClauses = []string{"UNION", "INTERSECT", "EXCEPT"}
+
+// rejectSetOperations adds error to statements that have set operation clauses
+func rejectSetOperations(db *gorm.DB) {
+	for _, name := range setOperationClauses {
+		if _, ok := db.Statement.Clauses[name]; ok {
+			db.AddError(fmt.Errorf("%w: %s", ErrUnsupportedSetOperation, name))
+		}
+	}
+}
+
+// rejectSoftDeleteUsing adds error to delete statements that have DELETE USING clause on soft deleted model,
+// as the statement is turned into UPDATE without the joined tables
+func rejectSoftDeleteUsing(db *gorm.DB) {
+	if _, ok := db.Statement.Clauses["DELETE USING"]; !ok || db.Statement.Unscoped {
+		return
+	}
+	if db.Statement.Schema != nil && len(db.Statement.Schema.DeleteClauses) > 0 {
+		db.AddError(ErrUnsupportedSoftDelete)
+	}
+}
+
+// setOperationValidator is implemented by set operation clauses that can validate their branches against the statement
+type setOperationValidator interface {
+	Validate(stmt *gorm.Statement) error
+}
+
+// validateSetOperations adds error of set operation clauses which branches are not compatible
+func validateSetOperations(db *gorm.DB) {
+	for _, name := range setOperationClauses {
+		if c, ok := db.Statement.Clauses[name]; ok {
+			if validator, ok := c.Expression.(setOperationValidator); ok {
+				if err := validator.Validate(db.Statement); err != nil {
+					db.AddError(err)
+				}
+			}
+		}
+	}
+}
+
 func merge(origin []string, pluginClauses []pluginClause) []string {
 	collect := func(target string) []string {
 		found := []string{}
 		for _, clause := range pluginClauses {
-			if clause.before == target {
+			// clauses that already exist, such as customized WITH, are kept in their position
+			if clause.before == target && !slices.Contains(origin, clause.name) {
 				found = append(found, clause.name)
 			}
 		}
@@ -68,5 +181,5 @@ func merge(origin []string, pluginClauses []pluginClause) []string {
 		result = append(result, appendClauses...)
 		result = append(result, clause)
 	}
-	return slices.Compact(result)
+	return append(result, collect("")...)
 }
@@ -1,12 +1,15 @@
 package gormextraclauseplugin
 
 import (
+	"errors"
 	"slices"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/WinterYukky/gorm-extra-clause-plugin/exclause"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func TestInstall(t *testing.T) {
@@ -30,6 +33,24 @@ func TestInstall(t *testing.T) {
 	}
 }
 
+func TestCreateClauses_Default(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}))
+	db.Use(New())
+	got := db.Callback().Create().Clauses
+	want := []string{"COMMENT", "WITH", "INSERT", "VALUES", "ON CONFLICT"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Create clauses is %v, want %v", got, want)
+	}
+}
+
 func TestQueryClauses_Default(t *testing.T) {
 	mockDB, _, err := sqlmock.New()
 	if err != nil {
@@ -42,7 +63,7 @@ func TestQueryClauses_Default(t *testing.T) {
 	}))
 	db.Use(New())
 	got := db.Callback().Query().Clauses
-	want := []string{"WITH", "SELECT", "FROM", "WHERE", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "LIMIT", "FOR"}
+	want := []string{"COMMENT", "WITH", "SELECT", "FROM", "WHERE", "CONNECT BY", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "FETCH", "LIMIT", "FOR"}
 	if !slices.Equal(got, want) {
 		t.Errorf("Query clauses is %v, want %v", got, want)
 	}
@@ -60,7 +81,7 @@ func TestQueryClauses_Customized(t *testing.T) {
 	db.Callback().Query().Clauses = []string{"FOO", "SELECT", "FROM", "WHERE", "BAR", "GROUP BY", "ORDER BY", "LIMIT", "FOR"}
 	db.Use(New())
 	got := db.Callback().Query().Clauses
-	want := []string{"FOO", "WITH", "SELECT", "FROM", "WHERE", "BAR", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "LIMIT", "FOR"}
+	want := []string{"FOO", "COMMENT", "WITH", "SELECT", "FROM", "WHERE", "BAR", "CONNECT BY", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "FETCH", "LIMIT", "FOR"}
 	if !slices.Equal(got, want) {
 		t.Errorf("Query clauses is %v, want %v", got, want)
 	}
@@ -78,7 +99,7 @@ func TestRowClauses_Default(t *testing.T) {
 	}))
 	db.Use(New())
 	got := db.Callback().Row().Clauses
-	want := []string{"WITH", "SELECT", "FROM", "WHERE", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "LIMIT", "FOR"}
+	want := []string{"COMMENT", "WITH", "SELECT", "FROM", "WHERE", "CONNECT BY", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "FETCH", "LIMIT", "FOR"}
 	if !slices.Equal(got, want) {
 		t.Errorf("Row clauses is %v, want %v", got, want)
 	}
@@ -96,7 +117,7 @@ func TestRowClauses_Customized(t *testing.T) {
 	db.Callback().Row().Clauses = []string{"FOO", "SELECT", "FROM", "WHERE", "BAR", "GROUP BY", "ORDER BY", "LIMIT", "FOR"}
 	db.Use(New())
 	got := db.Callback().Row().Clauses
-	want := []string{"FOO", "WITH", "SELECT", "FROM", "WHERE", "BAR", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "LIMIT", "FOR"}
+	want := []string{"FOO", "COMMENT", "WITH", "SELECT", "FROM", "WHERE", "BAR", "CONNECT BY", "GROUP BY", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "FETCH", "LIMIT", "FOR"}
 	if !slices.Equal(got, want) {
 		t.Errorf("Row clauses is %v, want %v", got, want)
 	}
@@ -114,7 +135,7 @@ func TestUpdateClauses_Default(t *testing.T) {
 	}))
 	db.Use(New())
 	got := db.Callback().Update().Clauses
-	want := []string{"WITH", "UPDATE", "SET", "WHERE", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "LIMIT"}
+	want := []string{"COMMENT", "WITH", "UPDATE", "SET", "OUTPUT", "UPDATE FROM", "WHERE", "ORDER BY", "LIMIT"}
 	if !slices.Equal(got, want) {
 		t.Errorf("Update clauses is %v, want %v", got, want)
 	}
@@ -132,8 +153,108 @@ func TestUpdateClauses_Customized(t *testing.T) {
 	db.Callback().Update().Clauses = []string{"FOO", "WITH", "UPDATE", "SET", "WHERE", "BAR", "ORDER BY", "BAZ", "LIMIT", "FOR"}
 	db.Use(New())
 	got := db.Callback().Update().Clauses
-	want := []string{"FOO", "WITH", "UPDATE", "SET", "WHERE", "BAR", "UNION", "INTERSECT", "EXCEPT", "ORDER BY", "BAZ", "LIMIT", "FOR"}
+	want := []string{"FOO", "WITH", "COMMENT", "UPDATE", "SET", "OUTPUT", "UPDATE FROM", "WHERE", "BAR", "ORDER BY", "BAZ", "LIMIT", "FOR"}
 	if !slices.Equal(got, want) {
 		t.Errorf("Update clauses is %v, want %v", got, want)
 	}
 }
+
+func TestDeleteClauses_Default(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}))
+	db.Use(New())
+	got := db.Callback().Delete().Clauses
+	want := []string{"COMMENT", "WITH", "DELETE", "FROM", "OUTPUT", "DELETE USING", "WHERE", "ORDER BY", "LIMIT"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Delete clauses is %v, want %v", got, want)
+	}
+}
+func TestDeleteClauses_Customized(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}))
+	db.Callback().Delete().Clauses = []string{"FOO", "DELETE", "FROM", "BAR", "WHERE", "ORDER BY", "LIMIT"}
+	db.Use(New())
+	got := db.Callback().Delete().Clauses
+	want := []string{"FOO", "COMMENT", "WITH", "DELETE", "FROM", "BAR", "OUTPUT", "DELETE USING", "WHERE", "ORDER BY", "LIMIT"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Delete clauses is %v, want %v", got, want)
+	}
+}
+
+func TestWithSetOperationValidation(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts []Option
+		want bool
+	}{
+		{name: "When option is given, then should register validation", opts: []Option{WithSetOperationValidation()}, want: true},
+		{name: "When option is not given, then should not register validation", want: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, _, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db, _ := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}))
+			db.Use(New(tt.opts...))
+			if got := db.Callback().Query().Get("extra_clause:validate_set_operations") != nil; got != tt.want {
+				t.Errorf("query callback registered = %v, want %v", got, tt.want)
+			}
+			if got := db.Callback().Row().Get("extra_clause:validate_set_operations") != nil; got != tt.want {
+				t.Errorf("row callback registered = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRejectSetOperations(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		clause clause.Interface
+	}{
+		{name: "When UNION is given to UPDATE, then should be error", clause: exclause.NewUnion("SELECT * FROM `admin_users`")},
+		{name: "When INTERSECT is given to UPDATE, then should be error", clause: exclause.NewIntersect("SELECT * FROM `admin_users`")},
+		{name: "When EXCEPT is given to UPDATE, then should be error", clause: exclause.NewExcept("SELECT * FROM `admin_users`")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, _, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db, _ := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}))
+			db.Use(New())
+			db = db.Table("general_users").
+				Clauses(tt.clause).
+				Where("`id` = ?", 1).
+				Update("name", "new_name")
+			if !errors.Is(db.Error, ErrUnsupportedSetOperation) {
+				t.Errorf("error is %v, want %v", db.Error, ErrUnsupportedSetOperation)
+			}
+			if !errors.Is(db.Error, gorm.ErrInvalidData) {
+				t.Errorf("error is %v, want %v", db.Error, gorm.ErrInvalidData)
+			}
+		})
+	}
+}
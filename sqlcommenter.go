@@ -0,0 +1,114 @@
+package gormextraclauseplugin
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// sqlCommenterClause is the clause name of sqlcommenter comment, that is written at the end of statements
+const sqlCommenterClause = "SQL COMMENTER"
+
+// WithSQLCommenter appends sqlcommenter format comment built from the context values to the statements,
+// such as SELECT * FROM `users` /*route='%2Fusers',traceparent='00-...-01'*/.
+// keys maps the comment keys to the context keys, and the values are formatted by fmt.Sprint.
+// Context values that don't exist are omitted.
+// The comment is added only when the statement is executed, so SQL of DryRun mode and ToSQL doesn't include it.
+// gorm builds subqueries in DryRun mode, and they would be commented otherwise.
+//
+//	// example
+//	db.Use(extraClausePlugin.New(extraClausePlugin.WithSQLCommenter(map[string]interface{}{
+//		"route":       routeKey{},
+//		"traceparent": traceparentKey{},
+//	})))
+//	db.WithContext(ctx).Find(&users)
+func WithSQLCommenter(keys map[string]interface{}) Option {
+	return func(e *ExtraClausePlugin) {
+		e.sqlCommenterKeys = keys
+	}
+}
+
+// registerSQLCommenter registers sqlcommenter clause and callbacks to all statements
+func (e *ExtraClausePlugin) registerSQLCommenter(db *gorm.DB) error {
+	clauses := []pluginClause{{name: sqlCommenterClause}}
+	db.Callback().Create().Clauses = merge(db.Callback().Create().Clauses, clauses)
+	db.Callback().Query().Clauses = merge(db.Callback().Query().Clauses, clauses)
+	db.Callback().Row().Clauses = merge(db.Callback().Row().Clauses, clauses)
+	db.Callback().Update().Clauses = merge(db.Callback().Update().Clauses, clauses)
+	db.Callback().Delete().Clauses = merge(db.Callback().Delete().Clauses, clauses)
+
+	const name = "extra_clause:sql_commenter"
+	callback := e.addSQLComment
+	if err := db.Callback().Create().Before("gorm:create").Register(name, callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register(name, callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register(name, callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register(name, callback); err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register(name, callback)
+}
+
+// addSQLComment adds sqlcommenter comment of the context values to the statement
+func (e *ExtraClausePlugin) addSQLComment(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || db.Statement.Context == nil {
+		return
+	}
+	tags := map[string]string{}
+	for key, contextKey := range e.sqlCommenterKeys {
+		if value := db.Statement.Context.Value(contextKey); value != nil {
+			tags[key] = fmt.Sprint(value)
+		}
+	}
+	if len(tags) == 0 {
+		delete(db.Statement.Clauses, sqlCommenterClause)
+		return
+	}
+	db.Statement.AddClause(sqlComment{Tags: tags})
+}
+
+// sqlComment is sqlcommenter format comment
+type sqlComment struct {
+	Tags map[string]string
+}
+
+// Name sql comment clause name
+func (comment sqlComment) Name() string {
+	return sqlCommenterClause
+}
+
+// Build build sqlcommenter comment.
+// Keys are sorted, and keys and values are URL encoded, which also encodes the quotes in the values.
+func (comment sqlComment) Build(builder clause.Builder) {
+	keys := make([]string, 0, len(comment.Tags))
+	for key := range comment.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	builder.WriteString("/*")
+	for index, key := range keys {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(url.QueryEscape(key))
+		builder.WriteString("='")
+		builder.WriteString(url.QueryEscape(comment.Tags[key]))
+		builder.WriteByte('\'')
+	}
+	builder.WriteString("*/")
+}
+
+// MergeClause replaces the sql comment, as the context is given for each execution
+func (comment sqlComment) MergeClause(mergeClause *clause.Clause) {
+	mergeClause.Name = ""
+	mergeClause.Expression = comment
+}
@@ -0,0 +1,160 @@
+package gormextraclauseplugin
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/WinterYukky/gorm-extra-clause-plugin/exclause"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type commenterKey string
+
+func TestWithSQLCommenter(t *testing.T) {
+	keys := map[string]interface{}{
+		"route":       commenterKey("route"),
+		"traceparent": commenterKey("traceparent"),
+	}
+	ctx := context.WithValue(context.Background(), commenterKey("route"), "/users")
+	ctx = context.WithValue(ctx, commenterKey("traceparent"), "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	comment := "/*route='%2Fusers',traceparent='00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01'*/"
+
+	tests := []struct {
+		name      string
+		exec      bool
+		operation func(db *gorm.DB) *gorm.DB
+		want      string
+		wantArgs  []driver.Value
+	}{
+		{
+			name: "When query has context values, then should append comment",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.WithContext(ctx).Table("users").Where("`name` = ?", "WinterYukky").Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `name` = ? " + comment,
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When values need encoding, then should be URL encoded",
+			operation: func(db *gorm.DB) *gorm.DB {
+				ctx := context.WithValue(context.Background(), commenterKey("route"), "/users/it's me")
+				return db.WithContext(ctx).Table("users").Scan(nil)
+			},
+			want:     "SELECT * FROM `users` /*route='%2Fusers%2Fit%27s+me'*/",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When context has no values, then should not append comment",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.WithContext(context.Background()).Table("users").Scan(nil)
+			},
+			want:     "SELECT * FROM `users`",
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When query has subquery, then should append comment only to the statement",
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.WithContext(ctx).Table("users").Where("`id` IN (?)", db.WithContext(ctx).Table("admins").Select("user_id")).Scan(nil)
+			},
+			want:     "SELECT * FROM `users` WHERE `id` IN (SELECT user_id FROM `admins`) " + comment,
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When count is wrapped, then should append comment to the outer query",
+			operation: func(db *gorm.DB) *gorm.DB {
+				var count int64
+				return db.WithContext(ctx).Table("general_users").Clauses(exclause.NewUnion(db.Table("admin_users"))).Count(&count)
+			},
+			want:     "SELECT count(*) FROM (SELECT * FROM `general_users` UNION SELECT * FROM `admin_users`) AS `t` " + comment,
+			wantArgs: []driver.Value{},
+		},
+		{
+			name: "When create has context values, then should append comment",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.WithContext(ctx).Table("users").Create(map[string]interface{}{"name": "WinterYukky"})
+			},
+			want:     "INSERT INTO `users` (`name`) VALUES (?) " + comment,
+			wantArgs: []driver.Value{"WinterYukky"},
+		},
+		{
+			name: "When update has context values, then should append comment",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.WithContext(ctx).Table("users").Where("`id` = ?", 1).Update("name", "WinterYukky")
+			},
+			want:     "UPDATE `users` SET `name`=? WHERE `id` = ? " + comment,
+			wantArgs: []driver.Value{"WinterYukky", 1},
+		},
+		{
+			name: "When delete has context values, then should append comment",
+			exec: true,
+			operation: func(db *gorm.DB) *gorm.DB {
+				return db.WithContext(ctx).Table("users").Where("`id` = ?", 1).Delete(nil)
+			},
+			want:     "DELETE FROM `users` WHERE `id` = ? " + comment,
+			wantArgs: []driver.Value{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db, _ := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}))
+			db.Use(New(WithSQLCommenter(keys)))
+			if tt.exec {
+				mock.ExpectBegin()
+				mock.ExpectExec("^" + regexp.QuoteMeta(tt.want) + "$").WithArgs(tt.wantArgs...).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectQuery("^" + regexp.QuoteMeta(tt.want) + "$").WithArgs(tt.wantArgs...).WillReturnRows(sqlmock.NewRows([]string{}))
+			}
+
+			db = tt.operation(db)
+			if db.Error != nil {
+				t.Errorf(db.Error.Error())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWithSQLCommenter_Clauses(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db, _ := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}))
+	db.Use(New(WithSQLCommenter(map[string]interface{}{"route": commenterKey("route")})))
+	for name, clauses := range map[string][]string{
+		"Create": db.Callback().Create().Clauses,
+		"Query":  db.Callback().Query().Clauses,
+		"Row":    db.Callback().Row().Clauses,
+		"Update": db.Callback().Update().Clauses,
+		"Delete": db.Callback().Delete().Clauses,
+	} {
+		if got := clauses[len(clauses)-1]; got != sqlCommenterClause {
+			t.Errorf("last clause of %s is %v, want %v", name, got, sqlCommenterClause)
+		}
+		if slices.Index(clauses, sqlCommenterClause) != len(clauses)-1 {
+			t.Errorf("%s clauses %v has duplicated %v", name, clauses, sqlCommenterClause)
+		}
+	}
+}